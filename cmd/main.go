@@ -5,21 +5,17 @@ import (
 	"cryptoswap/internal/config"
 	"cryptoswap/internal/lib/api"
 	"cryptoswap/internal/lib/db"
-	"cryptoswap/internal/lib/httpclient"
 	"cryptoswap/internal/lib/logger"
 	"cryptoswap/internal/lib/messaging"
 	"cryptoswap/internal/lib/middlewares"
 	"cryptoswap/internal/lib/server"
 	"cryptoswap/internal/repository/currencies"
-	"cryptoswap/internal/repository/http/changenow"
-	"cryptoswap/internal/repository/http/coingecko"
-	"cryptoswap/internal/repository/http/stealthex"
+	"cryptoswap/internal/repository/http/registry"
 	"cryptoswap/internal/repository/rabbitmq"
 	currService "cryptoswap/internal/services/currencies"
 	"cryptoswap/internal/services/daemon"
 	"cryptoswap/internal/transport/consumer"
 	currHandlers "cryptoswap/internal/transport/handlers/handlers"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -49,39 +45,26 @@ func main() {
 	}
 
 	// Repositories:
-	changenow := changenow.NewChangeNowRepository(fact.NewLogger("changenow"),
-		httpclient.NewFactory(httpclient.HttpConfig{
-			ApiKey:     cfg.Exchanges.ChangeNow.ApiKey,
-			AuthHeader: cfg.Exchanges.ChangeNow.AuthHeader,
-			Timeout:    time.Duration(cfg.Exchanges.ChangeNow.TimeoutSeconds) * time.Second,
-			BaseURL:    cfg.Exchanges.ChangeNow.BaseURL,
-		}, fact.NewLogger("http_client")))
-
-	stealthex := stealthex.NewStealthExRepository(fact.NewLogger("stealthex"),
-		httpclient.NewFactory(httpclient.HttpConfig{
-			BaseURL:    cfg.Exchanges.StealthEx.BaseURL,
-			Timeout:    time.Duration(cfg.Exchanges.StealthEx.TimeoutSeconds) * time.Second,
-			ApiKey:     cfg.Exchanges.StealthEx.ApiKey,
-			AuthScheme: cfg.Exchanges.StealthEx.AuthScheme,
-		}, fact.NewLogger("http_client")))
-
-	coingecko := coingecko.NewCoinGecko(fact.NewLogger("coingecko"), httpclient.NewFactory(httpclient.HttpConfig{
-		ApiKey:     cfg.Exchanges.CoinGecko.ApiKey,
-		AuthHeader: cfg.Exchanges.CoinGecko.AuthHeader,
-		Timeout:    time.Duration(cfg.Exchanges.CoinGecko.TimeoutSeconds) * time.Second,
-		BaseURL:    cfg.Exchanges.CoinGecko.BaseURL,
-	}, fact.NewLogger("http_client")))
+	exchanges, err := registry.NewRegistry(fact, cfg.Exchanges)
+	if err != nil {
+		mainLogger.Fatalf(ctx, "error building exchanges: %v", err)
+	}
 
 	currDB := currencies.NewDB(fact.NewLogger("database"), db)
 
 	msgNotifier := rabbitmq.NewExchangeNotifier(fact.NewLogger("messaging"), msgConn)
 
 	// Services:
-	currencyManager := daemon.NewCurrencyManager(fact.NewLogger("daemon"), currDB, coingecko,
-		changenow, stealthex)
+	currencyManager := daemon.NewCurrencyManager(fact.NewLogger("daemon"), currDB,
+		exchanges.GetCashFetcher(), exchanges.GetCurrencyFetchers()...)
 
 	currencyService := currService.NewCurrencyService(fact.NewLogger("currency_service"), currDB,
-		msgNotifier, changenow, stealthex)
+		msgNotifier, exchanges.GetCurrencyFetchers()...).
+		WithWeights(exchanges.GetWeights()).
+		WithBreakers(exchanges.GetBreakers()).
+		WithQuotesDeadline(cfg.Quotes.GetDeadline()).
+		WithQuotesCache(cfg.Quotes.GetCacheTTL(), cfg.Quotes.AmountPrecision).
+		WithSplit(cfg.Quotes.GetSplitBuckets(), cfg.Quotes.GetSplitMaxParts())
 
 	// Handlers:
 	currencyHandler := currHandlers.NewHandlers(fact.NewLogger("handlers"),
@@ -3,7 +3,7 @@ CREATE SCHEMA IF NOT EXISTS cryptoswap;
 USE cryptoswap;
 
 CREATE TABLE currency (
-    symbol VARCHAR(16) NOT NULL,
+    symbol VARCHAR(32) NOT NULL,
     name VARCHAR(100) NOT NULL,
     image VARCHAR(255) NOT NULL,
     available BOOLEAN NOT NULL,
@@ -14,29 +14,96 @@ CREATE TABLE currency (
 );
 
 CREATE TABLE currencies_networks (
-    symbol VARCHAR(16) NOT NULL,
+    symbol VARCHAR(32) NOT NULL,
     network VARCHAR(100) NOT NULL,
+    contract_address VARCHAR(255),
+    has_extra_id BOOLEAN NOT NULL DEFAULT FALSE,
+    extra_id_validation VARCHAR(255),
+    address_explorer VARCHAR(255),
+    tx_explorer VARCHAR(255),
     PRIMARY KEY (symbol, network),
+    UNIQUE KEY uq_currencies_networks_contract (network, contract_address),
     FOREIGN KEY (symbol) REFERENCES currency(symbol)
 );
 
+CREATE TABLE currencies_networks_exchanges (
+    symbol VARCHAR(32) NOT NULL,
+    network VARCHAR(100) NOT NULL,
+    exchange VARCHAR(100) NOT NULL,
+    ticker VARCHAR(32),
+    warnings_from JSON,
+    warnings_to JSON,
+    PRIMARY KEY (symbol, network, exchange),
+    FOREIGN KEY (symbol, network) REFERENCES currencies_networks(symbol, network)
+);
+
 
 CREATE TABLE swap (
     id VARCHAR(50) NOT NULL,
-    from_symbol VARCHAR(16) NOT NULL,
+    parent_id VARCHAR(50),
+    from_symbol VARCHAR(32) NOT NULL,
     from_network VARCHAR(100) NOT NULL,
-    to_symbol VARCHAR(16) NOT NULL,
+    to_symbol VARCHAR(32) NOT NULL,
     to_network VARCHAR(100) NOT NULL,
     exchange_id VARCHAR(255) NOT NULL,
     payin_amount DECIMAL(38, 18) NOT NULL,
     payout_amount DECIMAL(38, 18) NOT NULL,
     payout_address VARCHAR(255) NOT NULL,
+    payout_extra_id VARCHAR(255),
     reason TEXT,
     to_address VARCHAR(255) NOT NULL,
+    to_extra_id VARCHAR(255),
     refund_address VARCHAR(255) NOT NULL,
+    refund_extra_id VARCHAR(255),
+    rate_id VARCHAR(255),
+    payin_hash VARCHAR(255),
+    payout_hash VARCHAR(255),
     exchange VARCHAR(100) NOT NULL,
     status VARCHAR(100) NOT NULL,
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
-    PRIMARY KEY (id)
+    PRIMARY KEY (id),
+    KEY idx_swap_parent (parent_id),
+    FOREIGN KEY (parent_id) REFERENCES swap(id)
+);
+
+CREATE TABLE quote_history (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    fan_out_id VARCHAR(50) NOT NULL,
+    exchange VARCHAR(100) NOT NULL,
+    from_symbol VARCHAR(32) NOT NULL,
+    from_network VARCHAR(100) NOT NULL,
+    to_symbol VARCHAR(32) NOT NULL,
+    to_network VARCHAR(100) NOT NULL,
+    rate_mode VARCHAR(16) NOT NULL,
+    amount_type VARCHAR(16) NOT NULL,
+    from_amount DECIMAL(38, 18) NOT NULL,
+    amount DECIMAL(38, 18) NOT NULL,
+    difference DOUBLE,
+    latency_ms INT NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    error_category VARCHAR(32),
+    won BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    KEY idx_quote_history_pair (from_symbol, from_network, to_symbol, to_network, created_at)
+);
+
+CREATE TABLE price_snapshot (
+    symbol VARCHAR(32) NOT NULL,
+    taken_at DATETIME NOT NULL,
+    price DECIMAL(38, 18) NOT NULL,
+    change_24h DOUBLE NOT NULL,
+    PRIMARY KEY (symbol, taken_at)
+);
+
+CREATE TABLE price_candle (
+    symbol VARCHAR(32) NOT NULL,
+    candle_interval VARCHAR(4) NOT NULL,
+    open_time DATETIME NOT NULL,
+    open_price DECIMAL(38, 18) NOT NULL,
+    high_price DECIMAL(38, 18) NOT NULL,
+    low_price DECIMAL(38, 18) NOT NULL,
+    close_price DECIMAL(38, 18) NOT NULL,
+    PRIMARY KEY (symbol, candle_interval, open_time)
 );
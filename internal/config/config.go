@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"strconv"
+	"time"
 )
 
 type Config struct {
@@ -9,6 +11,7 @@ type Config struct {
 	Logger    Logger    `yaml:"logger"`
 	Database  Database  `yaml:"database"`
 	Exchanges Exchanges `yaml:"exchanges"`
+	Quotes    Quotes    `yaml:"quotes"`
 	Daemon    Daemon    `yaml:"daemon"`
 	Messaging RabbitMQ  `yaml:"messaging"`
 }
@@ -21,6 +24,45 @@ func (c *Config) IsDaemonEnabled() bool {
 	return c.Daemon.Enabled == "true"
 }
 
+type Quotes struct {
+	DeadlineSeconds int `yaml:"deadline_seconds"`
+	CacheTTLSeconds int `yaml:"cache_ttl_seconds"`
+	// AmountPrecision is the significant digits two amounts share to be
+	// served the same cached quotes.
+	AmountPrecision int `yaml:"amount_precision"`
+	// SplitBuckets is how many parts the amount of a split quote is cut in,
+	// each exchange being quoted for every multiple of a part.
+	SplitBuckets  int `yaml:"split_buckets"`
+	SplitMaxParts int `yaml:"split_max_parts"`
+}
+
+func (q Quotes) GetCacheTTL() time.Duration {
+	return time.Duration(q.CacheTTLSeconds) * time.Second
+}
+
+// GetDeadline returns how long the quotes wait for the exchanges.
+func (q Quotes) GetDeadline() time.Duration {
+	if q.DeadlineSeconds == 0 {
+		return 12 * time.Second
+	}
+	return time.Duration(q.DeadlineSeconds) * time.Second
+}
+
+func (q Quotes) GetSplitBuckets() int {
+	if q.SplitBuckets == 0 {
+		return 4
+	}
+	return q.SplitBuckets
+}
+
+// GetSplitMaxParts returns how many exchanges a split can spread over.
+func (q Quotes) GetSplitMaxParts() int {
+	if q.SplitMaxParts == 0 {
+		return 3
+	}
+	return q.SplitMaxParts
+}
+
 type Server struct {
 	Port string `yaml:"port"`
 }
@@ -73,16 +115,102 @@ type Database struct {
 	Schema   string `yaml:"schema"`
 }
 
-type Exchanges struct {
-	ChangeNow Exchange `yaml:"change_now"`
-	StealthEx Exchange `yaml:"stealthex"`
-	CoinGecko Exchange `yaml:"coingecko"`
-}
+// Exchanges holds the exchange adapters settings, keyed by adapter name.
+type Exchanges map[string]Exchange
 
 type Exchange struct {
-	ApiKey         string `yaml:"api_key"`
-	AuthHeader     string `yaml:"auth_header"`
-	AuthScheme     string `yaml:"auth_scheme"`
-	TimeoutSeconds int    `yaml:"timeout_seconds"`
-	BaseURL        string `yaml:"base_url"`
+	Enabled        string  `yaml:"enabled"`
+	ApiKey         string  `yaml:"api_key"`
+	AuthHeader     string  `yaml:"auth_header"`
+	AuthScheme     string  `yaml:"auth_scheme"`
+	AuthQuery      string  `yaml:"auth_query"`
+	TimeoutSeconds int     `yaml:"timeout_seconds"`
+	BaseURL        string  `yaml:"base_url"`
+	Weight         float64 `yaml:"weight"`
+	MaxConcurrent  int     `yaml:"max_concurrent"`
+	CircuitBreaker Breaker `yaml:"circuit_breaker"`
+	Retry          Retry   `yaml:"retry"`
+}
+
+// IsEnabled tells whether the exchange adapter is built, exchanges are enabled
+// unless set to false.
+func (e Exchange) IsEnabled() (bool, error) {
+	if e.Enabled == "" {
+		return true, nil
+	}
+	enabled, err := strconv.ParseBool(e.Enabled)
+	if err != nil {
+		return false, fmt.Errorf("invalid enabled value %q", e.Enabled)
+	}
+	return enabled, nil
+}
+
+func (e Exchange) GetTimeout() time.Duration {
+	if e.TimeoutSeconds == 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(e.TimeoutSeconds) * time.Second
+}
+
+// GetWeight returns the factor applied to the exchange quotes when ranking
+// them, 1 when unset.
+func (e Exchange) GetWeight() float64 {
+	if e.Weight == 0 {
+		return 1
+	}
+	return e.Weight
+}
+
+// Breaker sets when an exchange stops being called after failing, and how it
+// is tried again.
+type Breaker struct {
+	FailureThreshold int `yaml:"failure_threshold"`
+	OpenSeconds      int `yaml:"open_seconds"`
+	HalfOpenCalls    int `yaml:"half_open_calls"`
+}
+
+func (b Breaker) GetFailureThreshold() int {
+	if b.FailureThreshold == 0 {
+		return 5
+	}
+	return b.FailureThreshold
+}
+
+func (b Breaker) GetOpenTimeout() time.Duration {
+	if b.OpenSeconds == 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(b.OpenSeconds) * time.Second
+}
+
+func (b Breaker) GetHalfOpenCalls() int {
+	if b.HalfOpenCalls == 0 {
+		return 1
+	}
+	return b.HalfOpenCalls
+}
+
+// Retry sets how the idempotent requests to an exchange are retried, they
+// aren't when MaxAttempts is under 2.
+type Retry struct {
+	MaxAttempts       int `yaml:"max_attempts"`
+	InitialBackoffMs  int `yaml:"initial_backoff_ms"`
+	MaxBackoffMs      int `yaml:"max_backoff_ms"`
+	MaxElapsedSeconds int `yaml:"max_elapsed_seconds"`
+}
+
+// withEnv resolves the ${VAR:-default} placeholders of every exchange, as the
+// config loader only resolves them on struct fields and not on map values.
+func (e Exchanges) withEnv() Exchanges {
+	resolved := make(Exchanges, len(e))
+	for name, exchange := range e {
+		exchange.Enabled = expandEnv(exchange.Enabled)
+		exchange.ApiKey = expandEnv(exchange.ApiKey)
+		exchange.AuthHeader = expandEnv(exchange.AuthHeader)
+		exchange.AuthScheme = expandEnv(exchange.AuthScheme)
+		exchange.AuthQuery = expandEnv(exchange.AuthQuery)
+		exchange.BaseURL = expandEnv(exchange.BaseURL)
+		resolved[name] = exchange
+	}
+	return resolved
 }
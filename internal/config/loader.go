@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"os"
+	"regexp"
 
 	ymlConf "github.com/Mth-Ryan/go-yaml-cfg"
 )
@@ -19,5 +20,25 @@ func LoadConfig() (Config, error) {
 		return Config{}, err
 	}
 
-	return ymlConf.GetConfigFromSingleton[Config]()
+	cfg, err := ymlConf.GetConfigFromSingleton[Config]()
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg.Exchanges = cfg.Exchanges.withEnv()
+	return cfg, nil
+}
+
+var envPattern = regexp.MustCompile(`\$\{([^}:]+)(:-([^}]*))?\}`)
+
+// expandEnv replaces the ${VAR} and ${VAR:-default} placeholders with the
+// environment values, using the default when the variable is empty.
+func expandEnv(value string) string {
+	return envPattern.ReplaceAllStringFunc(value, func(match string) string {
+		groups := envPattern.FindStringSubmatch(match)
+		if env := os.Getenv(groups[1]); env != "" {
+			return env
+		}
+		return groups[3]
+	})
 }
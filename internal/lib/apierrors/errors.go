@@ -28,3 +28,7 @@ func NewApiError(def ErrorDefinition, err error) *ApiError {
 		err:             err,
 	}
 }
+
+func (e *ApiError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,118 @@
+// Package breaker guards calls to a remote dependency with a circuit breaker
+// and a cap on the calls in flight.
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type State string
+
+const (
+	StateClosed   State = "closed"
+	StateOpen     State = "open"
+	StateHalfOpen State = "half-open"
+)
+
+var (
+	ErrOpen         = errors.New("circuit breaker is open")
+	ErrBulkheadFull = errors.New("too many calls in flight")
+)
+
+type Config struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// circuit.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before letting trial
+	// calls through.
+	OpenTimeout time.Duration
+	// HalfOpenCalls is the number of trial calls allowed while half-open.
+	HalfOpenCalls int
+	// MaxConcurrent caps the calls in flight, 0 means no cap.
+	MaxConcurrent int
+}
+
+func NewBreaker(config Config) *Breaker {
+	return &Breaker{
+		config: config,
+		state:  StateClosed,
+		now:    time.Now,
+	}
+}
+
+type Breaker struct {
+	config   Config
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trials   int
+	inFlight int
+	now      func() time.Time
+}
+
+// Done reports the outcome of a call let through by Allow.
+type Done func(success bool)
+
+// Allow lets a call through, or returns ErrOpen or ErrBulkheadFull. The caller
+// must report the call outcome through the returned Done.
+func (b *Breaker) Allow() (Done, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.config.OpenTimeout {
+		b.state = StateHalfOpen
+		b.trials = 0
+	}
+
+	switch {
+	case b.state == StateOpen:
+		return nil, ErrOpen
+	case b.state == StateHalfOpen && b.trials >= b.config.HalfOpenCalls:
+		return nil, ErrOpen
+	case b.config.MaxConcurrent > 0 && b.inFlight >= b.config.MaxConcurrent:
+		return nil, ErrBulkheadFull
+	}
+
+	trial := b.state == StateHalfOpen
+	if trial {
+		b.trials++
+	}
+	b.inFlight++
+
+	return func(success bool) {
+		b.record(trial, success)
+	}, nil
+}
+
+func (b *Breaker) record(trial, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.inFlight--
+
+	// Calls started before the circuit opened don't decide its state anymore
+	if b.state == StateOpen || (b.state == StateHalfOpen && !trial) {
+		return
+	}
+
+	if success {
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.config.FailureThreshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+}
+
+func (b *Breaker) GetState() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
@@ -0,0 +1,108 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(config Config) (*Breaker, *time.Time) {
+	now := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+	b := NewBreaker(config)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func call(t *testing.T, b *Breaker, success bool) {
+	t.Helper()
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("expected the call to be allowed, got %v", err)
+	}
+	done(success)
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	b, _ := newTestBreaker(Config{FailureThreshold: 3, OpenTimeout: time.Minute, HalfOpenCalls: 1})
+
+	call(t, b, false)
+	call(t, b, false)
+	call(t, b, true)
+	if b.GetState() != StateClosed {
+		t.Fatalf("a success should reset the failures, got %s", b.GetState())
+	}
+
+	for range 3 {
+		call(t, b, false)
+	}
+	if b.GetState() != StateOpen {
+		t.Fatalf("expected open, got %s", b.GetState())
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("expected ErrOpen, got %v", err)
+	}
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	b, now := newTestBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenCalls: 1})
+
+	call(t, b, false)
+	*now = now.Add(time.Minute)
+
+	// A single trial goes through once the timeout is over
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("expected a trial call, got %v", err)
+	}
+	if b.GetState() != StateHalfOpen {
+		t.Fatalf("expected half-open, got %s", b.GetState())
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Errorf("expected a single trial, got %v", err)
+	}
+
+	done(false)
+	if b.GetState() != StateOpen {
+		t.Fatalf("a failed trial should reopen, got %s", b.GetState())
+	}
+
+	*now = now.Add(time.Minute)
+	call(t, b, true)
+	if b.GetState() != StateClosed {
+		t.Fatalf("a successful trial should close, got %s", b.GetState())
+	}
+}
+
+func TestBreaker_StaleCallsDontDecide(t *testing.T) {
+	b, now := newTestBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenCalls: 1})
+
+	stale, _ := b.Allow()
+	call(t, b, false)
+	stale(true)
+	if b.GetState() != StateOpen {
+		t.Fatalf("a call started while closed shouldn't close the circuit, got %s", b.GetState())
+	}
+
+	*now = now.Add(time.Minute)
+	call(t, b, true)
+	if b.GetState() != StateClosed {
+		t.Fatalf("expected closed, got %s", b.GetState())
+	}
+}
+
+func TestBreaker_Bulkhead(t *testing.T) {
+	b, _ := newTestBreaker(Config{FailureThreshold: 1, OpenTimeout: time.Minute, MaxConcurrent: 2})
+
+	first, _ := b.Allow()
+	if _, err := b.Allow(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrBulkheadFull) {
+		t.Fatalf("expected ErrBulkheadFull, got %v", err)
+	}
+
+	first(true)
+	if _, err := b.Allow(); err != nil {
+		t.Errorf("expected a free slot, got %v", err)
+	}
+}
@@ -1,5 +1,6 @@
 package constants
 
 const (
-	SwapRoutingKey = "cryptoswap.swap"
+	SwapRoutingKey        = "cryptoswap.swap"
+	DelayedSwapRoutingKey = "delayed.cryptoswap.swap"
 )
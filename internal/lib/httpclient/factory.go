@@ -14,6 +14,8 @@ type HttpConfig struct {
 	AuthScheme string
 	Timeout    time.Duration
 	AuthHeader string
+	AuthQuery  string
+	Retry      RetryPolicy
 }
 
 func NewConfig(baseURL, apiKey, authScheme string, timeout time.Duration) HttpConfig {
@@ -51,22 +53,33 @@ func NewFactory(config HttpConfig, logger logger.Logger) Factory {
 		r = r.SetHeader(config.AuthHeader, config.ApiKey)
 	}
 
+	if config.AuthQuery != "" {
+		r = r.SetQueryParam(config.AuthQuery, config.ApiKey)
+	}
+
 	return &factory{
 		client: r,
 		logger: logger,
+		retry:  config.Retry,
+		sleep:  sleepContext,
 	}
 }
 
 type factory struct {
 	client *resty.Client
 	logger logger.Logger
+	retry  RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
 }
 
 func (f *factory) NewClient(ctx context.Context) HttpClient {
 	return &httpClient{
 		req: f.client.R().
+			SetContext(ctx).
 			SetHeaderMultiValues(f.client.Header),
 		ctx:    ctx,
 		logger: f.logger,
+		retry:  f.retry,
+		sleep:  f.sleep,
 	}
 }
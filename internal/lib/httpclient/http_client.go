@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -14,6 +15,10 @@ type httpClient struct {
 	req    *resty.Request
 	ctx    context.Context
 	logger logger.Logger
+	retry  RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+	// idempotent lets Post be retried like Get.
+	idempotent bool
 }
 
 type HttpClient interface {
@@ -22,6 +27,7 @@ type HttpClient interface {
 	WithApiKeyInQuery(apiKey string) HttpClient
 	WithQueryParams(key string, value any) HttpClient
 	WithBody(body any) HttpClient
+	WithIdempotent() HttpClient
 	Get(endpoint string) ([]byte, int, error)
 	Post(endpoint string) ([]byte, int, error)
 }
@@ -51,24 +57,68 @@ func (c *httpClient) WithBody(body any) HttpClient {
 	return c
 }
 
-func (c *httpClient) Get(endpoint string) ([]byte, int, error) {
-	response, err := c.req.Get(endpoint)
-	if err != nil {
-		c.logger.Errorf(c.ctx, "Error fetching %s, reason: %v", endpoint, err)
-		return nil, http.StatusInternalServerError, err
-	}
+// WithIdempotent marks the request as safe to send again, for the read-only
+// POSTs like quotes and ranges.
+func (c *httpClient) WithIdempotent() HttpClient {
+	c.idempotent = true
+	return c
+}
 
-	return response.Body(), response.StatusCode(), nil
+func (c *httpClient) Get(endpoint string) ([]byte, int, error) {
+	return c.send(endpoint, c.req.Get, c.retry)
 }
 
+// Post is only retried when marked idempotent, as it opens swaps on the
+// exchanges.
 func (c *httpClient) Post(endpoint string) ([]byte, int, error) {
-	response, err := c.req.SetHeader("Content-Type", "application/json").Post(endpoint)
-	if err != nil {
-		c.logger.Errorf(c.ctx, "Error fetching %s, reason: %v", endpoint, err)
-		return nil, http.StatusInternalServerError, err
+	c.req.SetHeader("Content-Type", "application/json")
+	policy := RetryPolicy{}
+	if c.idempotent {
+		policy = c.retry
 	}
+	return c.send(endpoint, c.req.Post, policy)
+}
+
+func (c *httpClient) send(endpoint string, method func(url string) (*resty.Response, error),
+	policy RetryPolicy) ([]byte, int, error) {
+	start := time.Now()
+	for retry := 0; ; retry++ {
+		response, err := method(endpoint)
+
+		status, header := http.StatusInternalServerError, http.Header{}
+		if err == nil {
+			status, header = response.StatusCode(), response.Header()
+		}
+
+		wait, ok := policy.nextWait(retry, status, header, err, time.Since(start))
+		if ok && c.ctx.Err() == nil {
+			c.logger.Warningf(c.ctx, "Retrying %s in %s, attempt %d got status %d: %v",
+				endpoint, wait, retry+1, status, err)
+			if sleepErr := c.sleep(c.ctx, wait); sleepErr == nil {
+				continue
+			}
+		}
+
+		if err != nil {
+			c.logger.Errorf(c.ctx, "Error fetching %s, reason: %v", endpoint, err)
+			return nil, http.StatusInternalServerError, err
+		}
+
+		return response.Body(), status, nil
+	}
+}
+
+// StatusError is returned by HandleRequest when the response status isn't the
+// expected one, keeping the response for the callers to inspect.
+type StatusError struct {
+	Endpoint   string
+	Expected   int
+	StatusCode int
+	Body       []byte
+}
 
-	return response.Body(), response.StatusCode(), nil
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("expected %d status code, got %d", e.Expected, e.StatusCode)
 }
 
 type Request func(endpoint string) ([]byte, int, error)
@@ -81,7 +131,12 @@ func HandleRequest[T any](request Request, endpoint string, wantStatus int) (T,
 	}
 
 	if gotStatus != wantStatus {
-		return cast, fmt.Errorf("expected %d status code, got %d", wantStatus, gotStatus)
+		return cast, &StatusError{
+			Endpoint:   endpoint,
+			Expected:   wantStatus,
+			StatusCode: gotStatus,
+			Body:       body,
+		}
 	}
 
 	if err := json.Unmarshal(body, &cast); err != nil {
@@ -0,0 +1,138 @@
+package httpclient
+
+import (
+	"context"
+	"cryptoswap/internal/lib/logger"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestFactory serves the statuses in order, repeating the last one, and
+// records the waits between attempts instead of sleeping.
+func newTestFactory(t *testing.T, policy RetryPolicy, header http.Header,
+	statuses ...int) (Factory, *atomic.Int32, *[]time.Duration) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(calls.Add(1)) - 1
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(statuses[min(call, len(statuses)-1)])
+		_, _ = w.Write([]byte(`{"message":"rate limited"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	waits := &[]time.Duration{}
+	f := NewFactory(HttpConfig{BaseURL: server.URL, Timeout: time.Second, Retry: policy},
+		logger.NewLoggerFactory("test", "fatal").NewLogger("http_client")).(*factory)
+	f.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return f, calls, waits
+}
+
+var testPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     time.Second,
+	MaxElapsed:     10 * time.Second,
+}
+
+func TestGet_RetriesWithBackoff(t *testing.T) {
+	f, calls, waits := newTestFactory(t, testPolicy, nil,
+		http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+
+	_, status, err := f.NewClient(context.Background()).Get("/")
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("expected a 200 after retrying, got %d: %v", status, err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls.Load())
+	}
+
+	// Jitter keeps each wait between half and the whole exponential backoff
+	for i, wait := range *waits {
+		backoff := testPolicy.InitialBackoff << i
+		if wait < backoff/2 || wait > backoff {
+			t.Errorf("wait %d: expected between %s and %s, got %s", i, backoff/2, backoff, wait)
+		}
+	}
+}
+
+func TestGet_HonoursRetryAfter(t *testing.T) {
+	f, _, waits := newTestFactory(t, testPolicy, http.Header{"Retry-After": {"2"}},
+		http.StatusTooManyRequests, http.StatusOK)
+
+	if _, status, _ := f.NewClient(context.Background()).Get("/"); status != http.StatusOK {
+		t.Fatalf("expected a 200 after retrying, got %d", status)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 2*time.Second {
+		t.Errorf("expected to wait the Retry-After, got %v", *waits)
+	}
+}
+
+func TestGet_StopsAtMaxElapsed(t *testing.T) {
+	policy := testPolicy
+	policy.MaxElapsed = time.Second
+	f, calls, _ := newTestFactory(t, policy, http.Header{"Retry-After": {"5"}},
+		http.StatusTooManyRequests)
+
+	_, status, _ := f.NewClient(context.Background()).Get("/")
+	if status != http.StatusTooManyRequests || calls.Load() != 1 {
+		t.Errorf("expected a single attempt returning 429, got %d after %d", status, calls.Load())
+	}
+}
+
+func TestGet_DoesntRetryClientErrors(t *testing.T) {
+	f, calls, _ := newTestFactory(t, testPolicy, nil, http.StatusBadRequest)
+
+	if _, status, _ := f.NewClient(context.Background()).Get("/"); status != http.StatusBadRequest {
+		t.Fatalf("expected a 400, got %d", status)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestPost_IsNotRetried(t *testing.T) {
+	f, calls, _ := newTestFactory(t, testPolicy, nil, http.StatusServiceUnavailable, http.StatusOK)
+
+	if _, status, _ := f.NewClient(context.Background()).Post("/"); status != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503, got %d", status)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", calls.Load())
+	}
+}
+
+func TestPost_RetriedWhenIdempotent(t *testing.T) {
+	f, calls, _ := newTestFactory(t, testPolicy, nil, http.StatusServiceUnavailable, http.StatusOK)
+
+	if _, status, _ := f.NewClient(context.Background()).WithIdempotent().Post("/"); status != http.StatusOK {
+		t.Fatalf("expected a 200 after retrying, got %d", status)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls.Load())
+	}
+}
+
+func TestHandleRequest_StatusError(t *testing.T) {
+	f, _, _ := newTestFactory(t, RetryPolicy{}, nil, http.StatusTooManyRequests)
+
+	_, err := HandleRequest[map[string]string](f.NewClient(context.Background()).Get, "/markets",
+		http.StatusOK)
+
+	statusErr := &StatusError{}
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected a StatusError, got %v", err)
+	}
+	if statusErr.StatusCode != http.StatusTooManyRequests || statusErr.Expected != http.StatusOK ||
+		statusErr.Endpoint != "/markets" || string(statusErr.Body) != `{"message":"rate limited"}` {
+		t.Errorf("unexpected error %+v", statusErr)
+	}
+}
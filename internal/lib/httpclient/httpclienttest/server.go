@@ -0,0 +1,141 @@
+// Package httpclienttest replays recorded exchange responses through an
+// httptest server, so adapters can be tested without network access.
+package httpclienttest
+
+import (
+	"cryptoswap/internal/lib/httpclient"
+	"cryptoswap/internal/lib/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Route is a recorded response, served to the requests matching its method,
+// path and query params.
+type Route struct {
+	Method  string
+	Path    string
+	Query   map[string]string
+	Status  int
+	Fixture string
+}
+
+func NewRoute(method, path string, status int, fixture string) Route {
+	return Route{
+		Method:  method,
+		Path:    path,
+		Status:  status,
+		Fixture: fixture,
+	}
+}
+
+// WithQuery restricts the route to the requests with the query param.
+func (r Route) WithQuery(key, value string) Route {
+	query := map[string]string{key: value}
+	for k, v := range r.Query {
+		query[k] = v
+	}
+	r.Query = query
+	return r
+}
+
+func (r Route) matches(req *http.Request) bool {
+	if req.Method != r.Method || req.URL.Path != r.Path {
+		return false
+	}
+
+	for key, value := range r.Query {
+		if req.URL.Query().Get(key) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Server answers with the fixture of the first matching route, read from the
+// testdata directory of the package under test. Unmatched requests get a 404.
+type Server struct {
+	*httptest.Server
+	t        *testing.T
+	routes   []Route
+	mu       sync.Mutex
+	requests []Request
+}
+
+func NewServer(t *testing.T, routes ...Route) *Server {
+	t.Helper()
+
+	s := &Server{t: t, routes: routes}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *Server) serve(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query(),
+		Header: req.Header.Clone(),
+		Body:   body,
+	})
+	s.mu.Unlock()
+
+	for _, route := range s.routes {
+		if !route.matches(req) {
+			continue
+		}
+
+		fixture, err := os.ReadFile(filepath.Join("testdata", route.Fixture))
+		if err != nil {
+			s.t.Errorf("reading fixture %s: %v", route.Fixture, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(route.Status)
+		_, _ = w.Write(fixture)
+		return
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// Factory returns an httpclient factory pointed at the server.
+func (s *Server) Factory() httpclient.Factory {
+	return httpclient.NewFactory(httpclient.HttpConfig{
+		BaseURL:    s.URL,
+		ApiKey:     "test-api-key",
+		AuthScheme: "Bearer",
+		Timeout:    5 * time.Second,
+	}, NewLogger("http_client"))
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+func NewLogger(name string) logger.Logger {
+	return logger.NewLoggerFactory("test", "error").NewLogger(name)
+}
@@ -0,0 +1,97 @@
+package httpclient
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy sets how idempotent requests are retried on transport errors
+// and on the statuses in retryableStatuses. The zero value makes a single
+// attempt.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// MaxElapsed bounds the time spent across all attempts and waits.
+	MaxElapsed time.Duration
+}
+
+var retryableStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+func (p RetryPolicy) isEnabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// backoff returns the wait before the given retry, exponential from
+// InitialBackoff and capped by MaxBackoff, jittered down to its half.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff << retry
+	if delay <= 0 || (p.MaxBackoff > 0 && delay > p.MaxBackoff) {
+		delay = p.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// nextWait returns how long to wait before another attempt, and false when
+// the request shouldn't be retried.
+func (p RetryPolicy) nextWait(retry int, status int, header http.Header, err error,
+	elapsed time.Duration) (time.Duration, bool) {
+	if retry+1 >= p.MaxAttempts {
+		return 0, false
+	}
+	if err == nil && !retryableStatuses[status] {
+		return 0, false
+	}
+
+	wait := p.backoff(retry)
+	if retryAfter, ok := parseRetryAfter(header); ok && (status == http.StatusTooManyRequests ||
+		status == http.StatusServiceUnavailable) {
+		wait = retryAfter
+	}
+
+	if p.MaxElapsed > 0 && elapsed+wait > p.MaxElapsed {
+		return 0, false
+	}
+	return wait, true
+}
+
+// parseRetryAfter reads the Retry-After header, in seconds or as a date.
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -5,6 +5,7 @@ import "github.com/google/uuid"
 const (
 	requestIdPrefix     = "RQ_"
 	swapRequestIdPrefix = "SWP_"
+	quoteFanOutIdPrefix = "QFO_"
 )
 
 func NewRequestId() string {
@@ -14,3 +15,7 @@ func NewRequestId() string {
 func NewSwapRequestId() string {
 	return swapRequestIdPrefix + uuid.New().String()
 }
+
+func NewQuoteFanOutId() string {
+	return quoteFanOutIdPrefix + uuid.New().String()
+}
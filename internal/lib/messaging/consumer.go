@@ -36,6 +36,7 @@ func (r *RabbitMQConnection) handleMessage(ctx context.Context, delivery amqp.De
 		Body:       delivery.Body,
 		Timestamp:  delivery.Timestamp,
 		RoutingKey: delivery.RoutingKey,
+		RequestId:  delivery.MessageId,
 	}
 
 	// Create a timeout context for message processing
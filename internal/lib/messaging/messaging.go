@@ -77,6 +77,13 @@ func (mb *MessageBuilder) WithRoutingKey(routingKey string) *MessageBuilder {
 	return mb
 }
 
+// WithDelay sets how long the message waits in the delay queue before being
+// routed again. It only has effect on routing keys bound to a delay queue.
+func (mb *MessageBuilder) WithDelay(delay time.Duration) *MessageBuilder {
+	mb.msg.Delay = delay
+	return mb
+}
+
 // Build returns the constructed message
 func (mb *MessageBuilder) Build() Message {
 	return mb.msg
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/streadway/amqp"
@@ -36,6 +37,11 @@ func (r *RabbitMQConnection) Publish(ctx context.Context, msg Message) error {
 		DeliveryMode: amqp.Persistent, // Make messages persistent
 	}
 
+	// Per-message TTL, the delay queue dead-letters it back once expired
+	if msg.Delay > 0 {
+		amqpMsg.Expiration = strconv.FormatInt(msg.Delay.Milliseconds(), 10)
+	}
+
 	return r.channel.Publish(
 		r.config.Exchange, // exchange
 		msg.RoutingKey,    // routing key
@@ -52,6 +52,7 @@ type Message struct {
 	RequestId  string
 	RoutingKey string
 	Body       []byte
+	Delay      time.Duration
 }
 
 type ConsumerBuilder interface {
@@ -0,0 +1,31 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Float64 decodes numbers that providers send either as JSON numbers or as
+// quoted strings ("0.123"). Empty strings and null decode to zero.
+type Float64 float64
+
+func (f *Float64) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), `"`)
+	if raw == "" || raw == "null" {
+		*f = 0
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s: %w", data, err)
+	}
+
+	*f = Float64(value)
+	return nil
+}
+
+func (f Float64) Value() float64 {
+	return float64(f)
+}
@@ -0,0 +1,45 @@
+// Package singleflight runs a single call at a time per key, the concurrent
+// callers with the same key share its result.
+package singleflight
+
+import "sync"
+
+type call[T any] struct {
+	wg    sync.WaitGroup
+	value T
+}
+
+type Group[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+// Do runs fn unless a call with the same key is in flight, in which case it
+// waits for it and returns its value. shared tells if the value came from
+// another caller.
+func (g *Group[T]) Do(key string, fn func() T) (value T, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*call[T]{}
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.value, true
+	}
+
+	c := &call[T]{}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.value = fn()
+	return c.value, false
+}
@@ -0,0 +1,55 @@
+package singleflight
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDo_CoalescesConcurrentCalls(t *testing.T) {
+	group := Group[int]{}
+	calls := atomic.Int32{}
+	release := make(chan struct{})
+
+	wg := sync.WaitGroup{}
+	results := make([]int, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = group.Do("btc:eth", func() int {
+				calls.Add(1)
+				<-release
+				return 42
+			})
+		}()
+	}
+
+	// Let every caller reach the group before the call ends
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("expected a single call, got %d", calls.Load())
+	}
+	for i, result := range results {
+		if result != 42 {
+			t.Errorf("caller %d: expected 42, got %d", i, result)
+		}
+	}
+}
+
+func TestDo_RunsAgainOnceDone(t *testing.T) {
+	group := Group[int]{}
+	calls := 0
+	for range 2 {
+		if _, shared := group.Do("btc:eth", func() int { calls++; return calls }); shared {
+			t.Error("expected a fresh call")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
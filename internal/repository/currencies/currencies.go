@@ -6,7 +6,11 @@ import (
 	"cryptoswap/internal/lib/logger"
 	"cryptoswap/internal/services/interfaces"
 	"cryptoswap/internal/services/models"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/samber/lo"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -30,7 +34,7 @@ func (cr *currenciesRepository) GetCurrencies(ctx context.Context,
 	filterMap := filters.ToMap()
 	entities := Currencies{}
 	if err := cr.db.WithContext(ctx).
-		Preload("Networks").
+		Preload("Networks.Exchanges").
 		Where(filterMap).
 		Find(&entities).
 		Error; err != nil {
@@ -41,6 +45,26 @@ func (cr *currenciesRepository) GetCurrencies(ctx context.Context,
 	return entities.ToModel(), nil
 }
 
+func (cr *currenciesRepository) GetCurrency(ctx context.Context, symbol string,
+) (models.Currency, *apierrors.ApiError) {
+	cr.logger.Infof(ctx, "Getting currency %s from the database", symbol)
+
+	entity := Currency{}
+	if err := cr.db.WithContext(ctx).
+		Preload("Networks.Exchanges").
+		Where("symbol = ?", symbol).
+		First(&entity).
+		Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Currency{}, apierrors.NewApiError(apierrors.NotFound,
+				fmt.Errorf("currency %s not found", symbol))
+		}
+		return models.Currency{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return entity.ToModel(), nil
+}
+
 func (cr *currenciesRepository) GetCurrenciesByPairs(ctx context.Context,
 	pairs ...models.NetworkPair) ([]models.Currency, *apierrors.ApiError) {
 	if len(pairs) == 0 {
@@ -53,7 +77,7 @@ func (cr *currenciesRepository) GetCurrenciesByPairs(ctx context.Context,
 
 	entities := Currencies{}
 	if err := cr.db.WithContext(ctx).
-		Preload("Networks").
+		Preload("Networks.Exchanges").
 		Where("symbol IN (?)", symbols).
 		Find(&entities).
 		Error; err != nil {
@@ -78,13 +102,17 @@ func (cr *currenciesRepository) InsertCurrencies(ctx context.Context, currencies
 			return err
 		}
 
-		// Delete ALL networks (careful!)
+		// Delete ALL networks and the exchanges listing them (careful!)
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).
+			Delete(&CurrencyNetworkExchange{}).Error; err != nil {
+			return err
+		}
 		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).
 			Delete(&CurrencyNetwork{}).Error; err != nil {
 			return err
 		}
 
-		// Flatten and re-insert networks
+		// Flatten and re-insert networks, along with their exchanges
 		var allNets []CurrencyNetwork
 		for _, entity := range entities {
 			for _, network := range entity.Networks {
@@ -149,6 +177,57 @@ func (cr *currenciesRepository) InsertSwap(ctx context.Context, swap models.Swap
 	return entity.ToModel(), nil
 }
 
+// GetChildSwaps returns the parts of a split swap, in the order they were created.
+func (cr *currenciesRepository) GetChildSwaps(ctx context.Context, parentId string,
+) ([]models.Swap, *apierrors.ApiError) {
+	cr.logger.Infof(ctx, "Getting child swaps from the database")
+
+	entities := []Swap{}
+	if err := cr.db.WithContext(ctx).
+		Where("parent_id = ?", parentId).
+		Order("created_at, id").
+		Find(&entities).Error; err != nil {
+		return nil, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return lo.Map(entities, func(entity Swap, _ int) models.Swap {
+		return entity.ToModel()
+	}), nil
+}
+
+// InsertSplitSwap inserts a split swap along with its parts, all or none.
+func (cr *currenciesRepository) InsertSplitSwap(ctx context.Context, swap models.Swap,
+) (models.Swap, *apierrors.ApiError) {
+	cr.logger.Infof(ctx, "Inserting split swap into the database")
+
+	parent := toSwapEntity(swap)
+	children := lo.Map(swap.Children, func(child models.Swap, _ int) Swap {
+		return toSwapEntity(child)
+	})
+	if err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&parent).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&children).Error; err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		return models.Swap{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	newSwap, err := cr.GetSwap(ctx, parent.Id)
+	if err != nil {
+		return models.Swap{}, err
+	}
+	newChildren, err := cr.GetChildSwaps(ctx, parent.Id)
+	if err != nil {
+		return models.Swap{}, err
+	}
+
+	return *newSwap.WithChildren(newChildren), nil
+}
+
 func (cr *currenciesRepository) UpdateSwap(ctx context.Context, swap models.Swap) *apierrors.ApiError {
 	cr.logger.Infof(ctx, "Updating swap in the database")
 
@@ -157,11 +236,146 @@ func (cr *currenciesRepository) UpdateSwap(ctx context.Context, swap models.Swap
 		Model(&Swap{}).
 		Where("id = ?", entity.Id).
 		Updates(map[string]any{
-			"status": entity.Status,
-			"reason": entity.Reason,
+			"status":      entity.Status,
+			"reason":      entity.Reason,
+			"payin_hash":  entity.PayinHash,
+			"payout_hash": entity.PayoutHash,
 		}).Error; err != nil {
 		return apierrors.NewApiError(apierrors.InternalServer, err)
 	}
 
 	return nil
 }
+
+func (cr *currenciesRepository) InsertQuoteRecords(ctx context.Context, records []models.QuoteRecord,
+) *apierrors.ApiError {
+	if len(records) == 0 {
+		return nil
+	}
+
+	entities := toQuoteHistoryEntities(records)
+	if err := cr.db.WithContext(ctx).Create(&entities).Error; err != nil {
+		return apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return nil
+}
+
+// GetExchangeStats aggregates the quote history of the pair since the given
+// time, by exchange. Only the answered quotes of priced currencies count for
+// the spread.
+func (cr *currenciesRepository) GetExchangeStats(ctx context.Context, from, to models.NetworkPair,
+	since time.Time) ([]models.ExchangeStats, *apierrors.ApiError) {
+	cr.logger.Infof(ctx, "Getting exchange stats from the database")
+
+	rows := []ExchangeStats{}
+	if err := cr.db.WithContext(ctx).
+		Model(&QuoteHistory{}).
+		Select(`exchange,
+			COUNT(*) AS requests,
+			SUM(status = ?) AS quotes,
+			SUM(won) AS wins,
+			COALESCE(AVG(CASE WHEN status = ? THEN difference END), 0) AS avg_difference,
+			AVG(latency_ms) AS avg_latency_ms,
+			MAX(latency_ms) AS max_latency_ms`, models.ExchangeStatusOk, models.ExchangeStatusOk).
+		Where("from_symbol = ? AND from_network = ? AND to_symbol = ? AND to_network = ? AND created_at >= ?",
+			from.Symbol, from.Network, to.Symbol, to.Network, since).
+		Group("exchange").
+		Order("exchange").
+		Scan(&rows).Error; err != nil {
+		return nil, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return lo.Map(rows, func(row ExchangeStats, _ int) models.ExchangeStats {
+		return row.ToModel()
+	}), nil
+}
+
+// InsertPriceSnapshots stores the snapshots and merges them into the candles
+// they fall in: the open price is kept, the high and low are widened and the
+// close is moved to the snapshot price.
+func (cr *currenciesRepository) InsertPriceSnapshots(ctx context.Context, snapshots []models.PriceSnapshot,
+) *apierrors.ApiError {
+	cr.logger.Infof(ctx, "Inserting %d price snapshots into the database", len(snapshots))
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	entities := toPriceSnapshotEntities(snapshots)
+	candles := toPriceCandleEntities(snapshots)
+	if err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entities).Error; err != nil {
+			return err
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]any{
+				"high_price":  gorm.Expr("GREATEST(high_price, VALUES(high_price))"),
+				"low_price":   gorm.Expr("LEAST(low_price, VALUES(low_price))"),
+				"close_price": gorm.Expr("VALUES(close_price)"),
+			}),
+		}).Create(&candles).Error
+	}); err != nil {
+		return apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return nil
+}
+
+// DeletePriceHistoryBefore drops the snapshots and the minute candles older
+// than the given time, the hourly and daily candles are kept.
+func (cr *currenciesRepository) DeletePriceHistoryBefore(ctx context.Context, before time.Time,
+) *apierrors.ApiError {
+	cr.logger.Infof(ctx, "Deleting price history before %s", before)
+
+	if err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("taken_at < ?", before).Delete(&PriceSnapshot{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("candle_interval = ? AND open_time < ?", models.CandleMinute, before).
+			Delete(&PriceCandle{}).Error
+	}); err != nil {
+		return apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return nil
+}
+
+// GetLatestPriceSnapshot returns the last snapshot of the currency, empty if
+// it was never priced.
+func (cr *currenciesRepository) GetLatestPriceSnapshot(ctx context.Context, symbol string,
+) (models.PriceSnapshot, *apierrors.ApiError) {
+	entities := []PriceSnapshot{}
+	if err := cr.db.WithContext(ctx).
+		Where("symbol = ?", symbol).
+		Order("taken_at DESC").
+		Limit(1).
+		Find(&entities).Error; err != nil {
+		return models.PriceSnapshot{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+	if len(entities) == 0 {
+		return models.PriceSnapshot{Symbol: symbol}, nil
+	}
+
+	return entities[0].ToModel(), nil
+}
+
+// GetCandles returns the last candles of the currency, oldest first.
+func (cr *currenciesRepository) GetCandles(ctx context.Context, symbol string, interval models.CandleInterval,
+	limit int) ([]models.Candle, *apierrors.ApiError) {
+	cr.logger.Infof(ctx, "Getting %s candles from the database", interval)
+
+	entities := []PriceCandle{}
+	if err := cr.db.WithContext(ctx).
+		Where("symbol = ? AND candle_interval = ?", symbol, interval).
+		Order("open_time DESC").
+		Limit(limit).
+		Find(&entities).Error; err != nil {
+		return nil, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	candles := lo.Map(entities, func(entity PriceCandle, _ int) models.Candle {
+		return entity.ToModel()
+	})
+	return lo.Reverse(candles), nil
+}
@@ -31,44 +31,100 @@ func (c Currency) TableName() string {
 }
 
 func (c *Currency) ToModel() models.Currency {
-	return models.Currency{
+	currency := models.Currency{
 		Symbol:            c.Symbol,
 		Name:              c.Name,
 		Image:             c.Image,
 		Available:         c.Available,
 		AddressValidation: c.AddressValidation,
 		Price:             c.Price,
-	}.WithNetworks(lo.Map(c.Networks, func(cn CurrencyNetwork, _ int) string {
-		return cn.Network
-	})...)
+	}
+	for _, network := range c.Networks {
+		currency = currency.WithNetwork(network.Network, network.ToModel())
+	}
+	return currency
 }
 
 type CurrencyNetwork struct {
-	Symbol  string `gorm:"column:symbol;primaryKey"`
-	Network string `gorm:"column:network;primaryKey"`
+	Symbol            string                    `gorm:"column:symbol;primaryKey"`
+	Network           string                    `gorm:"column:network;primaryKey"`
+	ContractAddress   *string                   `gorm:"column:contract_address"`
+	HasExtraId        bool                      `gorm:"column:has_extra_id"`
+	ExtraIdValidation string                    `gorm:"column:extra_id_validation"`
+	AddressExplorer   string                    `gorm:"column:address_explorer"`
+	TxExplorer        string                    `gorm:"column:tx_explorer"`
+	Exchanges         []CurrencyNetworkExchange `gorm:"foreignKey:Symbol,Network;references:Symbol,Network"`
+}
+
+func (cn CurrencyNetwork) ToModel() models.NetworkInfo {
+	info := models.NetworkInfo{
+		Contract:          lo.FromPtr(cn.ContractAddress),
+		HasExtraId:        cn.HasExtraId,
+		ExtraIdValidation: cn.ExtraIdValidation,
+		AddressExplorer:   cn.AddressExplorer,
+		TxExplorer:        cn.TxExplorer,
+		Exchanges: lo.Map(cn.Exchanges, func(exchange CurrencyNetworkExchange, _ int) string {
+			return exchange.Exchange
+		}),
+	}
+	for _, exchange := range cn.Exchanges {
+		info = info.WithWarnings(exchange.Exchange, exchange.ToWarnings())
+		if exchange.Ticker != "" {
+			info = info.WithTicker(exchange.Exchange, exchange.Ticker)
+		}
+	}
+	return info
 }
 
 func (cn CurrencyNetwork) TableName() string {
 	return "currencies_networks"
 }
 
+// CurrencyNetworkExchange is an exchange listing the currency on the network.
+type CurrencyNetworkExchange struct {
+	Symbol       string   `gorm:"column:symbol;primaryKey"`
+	Network      string   `gorm:"column:network;primaryKey"`
+	Exchange     string   `gorm:"column:exchange;primaryKey"`
+	Ticker       string   `gorm:"column:ticker"`
+	WarningsFrom []string `gorm:"column:warnings_from;serializer:json"`
+	WarningsTo   []string `gorm:"column:warnings_to;serializer:json"`
+}
+
+func (cne CurrencyNetworkExchange) ToWarnings() models.Warnings {
+	return models.Warnings{
+		From: cne.WarningsFrom,
+		To:   cne.WarningsTo,
+	}
+}
+
+func (cne CurrencyNetworkExchange) TableName() string {
+	return "currencies_networks_exchanges"
+}
+
 type Swap struct {
 	Id            string    `gorm:"column:id;primaryKey"`
+	ParentId      *string   `gorm:"column:parent_id"`
 	FromSymbol    string    `gorm:"column:from_symbol"`
 	FromNetwork   string    `gorm:"column:from_network"`
 	ToSymbol      string    `gorm:"column:to_symbol"`
 	ToNetwork     string    `gorm:"column:to_network"`
 	ToAddress     string    `gorm:"column:to_address"`
+	ToExtraId     string    `gorm:"column:to_extra_id"`
 	RefundAddress string    `gorm:"column:refund_address"`
+	RefundExtraId string    `gorm:"column:refund_extra_id"`
 	Exchange      string    `gorm:"column:exchange"`
 	Status        string    `gorm:"column:status"`
 	CreatedAt     time.Time `gorm:"column:created_at"`
 	UpdatedAt     time.Time `gorm:"column:updated_at"`
 	Reason        string    `gorm:"column:reason"`
 	PayoutAddress string    `gorm:"column:payout_address"`
+	PayoutExtraId string    `gorm:"column:payout_extra_id"`
 	PayoutAmount  float64   `gorm:"column:payout_amount"`
 	PayinAmount   float64   `gorm:"column:payin_amount"`
 	ExchangeId    string    `gorm:"column:exchange_id"`
+	RateId        string    `gorm:"column:rate_id"`
+	PayinHash     string    `gorm:"column:payin_hash"`
+	PayoutHash    string    `gorm:"column:payout_hash"`
 }
 
 func (s Swap) TableName() string {
@@ -78,9 +134,11 @@ func (s Swap) TableName() string {
 func (s Swap) ToModel() models.Swap {
 	return models.Swap{
 		Id:            s.Id,
+		ParentId:      lo.FromPtr(s.ParentId),
 		From:          models.NetworkPair{Symbol: s.FromSymbol, Network: s.FromNetwork},
 		To:            models.NetworkPair{Symbol: s.ToSymbol, Network: s.ToNetwork},
 		PayoutAddress: s.PayoutAddress,
+		PayoutExtraId: s.PayoutExtraId,
 		PayoutAmount:  s.PayoutAmount,
 		PayinAmount:   s.PayinAmount,
 		Status:        s.Status,
@@ -89,7 +147,106 @@ func (s Swap) ToModel() models.Swap {
 		Reason:        s.Reason,
 		Exchange:      s.Exchange,
 		ToAddress:     s.ToAddress,
+		ToExtraId:     s.ToExtraId,
 		RefundAddress: s.RefundAddress,
+		RefundExtraId: s.RefundExtraId,
 		ExchangeId:    s.ExchangeId,
+		RateId:        s.RateId,
+		PayinHash:     s.PayinHash,
+		PayoutHash:    s.PayoutHash,
+	}
+}
+
+// QuoteHistory is how an exchange answered one quote fan-out.
+type QuoteHistory struct {
+	Id            int64     `gorm:"column:id;primaryKey;autoIncrement"`
+	FanOutId      string    `gorm:"column:fan_out_id"`
+	Exchange      string    `gorm:"column:exchange"`
+	FromSymbol    string    `gorm:"column:from_symbol"`
+	FromNetwork   string    `gorm:"column:from_network"`
+	ToSymbol      string    `gorm:"column:to_symbol"`
+	ToNetwork     string    `gorm:"column:to_network"`
+	RateMode      string    `gorm:"column:rate_mode"`
+	AmountType    string    `gorm:"column:amount_type"`
+	FromAmount    float64   `gorm:"column:from_amount"`
+	Amount        float64   `gorm:"column:amount"`
+	Difference    *float64  `gorm:"column:difference"`
+	LatencyMs     int64     `gorm:"column:latency_ms"`
+	Status        string    `gorm:"column:status"`
+	ErrorCategory *string   `gorm:"column:error_category"`
+	Won           bool      `gorm:"column:won"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (qh QuoteHistory) TableName() string {
+	return "quote_history"
+}
+
+// ExchangeStats is a row of the quote history aggregated by exchange.
+type ExchangeStats struct {
+	Exchange      string  `gorm:"column:exchange"`
+	Requests      int     `gorm:"column:requests"`
+	Quotes        int     `gorm:"column:quotes"`
+	Wins          int     `gorm:"column:wins"`
+	AvgDifference float64 `gorm:"column:avg_difference"`
+	AvgLatencyMs  float64 `gorm:"column:avg_latency_ms"`
+	MaxLatencyMs  int64   `gorm:"column:max_latency_ms"`
+}
+
+func (es ExchangeStats) ToModel() models.ExchangeStats {
+	return models.ExchangeStats{
+		Exchange:      es.Exchange,
+		Requests:      es.Requests,
+		Quotes:        es.Quotes,
+		Wins:          es.Wins,
+		AvgDifference: es.AvgDifference,
+		AvgLatency:    time.Duration(es.AvgLatencyMs * float64(time.Millisecond)),
+		MaxLatency:    time.Duration(es.MaxLatencyMs) * time.Millisecond,
+	}
+}
+
+type PriceSnapshot struct {
+	Symbol    string    `gorm:"column:symbol;primaryKey"`
+	TakenAt   time.Time `gorm:"column:taken_at;primaryKey"`
+	Price     float64   `gorm:"column:price"`
+	Change24h float64   `gorm:"column:change_24h"`
+}
+
+func (ps PriceSnapshot) TableName() string {
+	return "price_snapshot"
+}
+
+func (ps PriceSnapshot) ToModel() models.PriceSnapshot {
+	return models.PriceSnapshot{
+		Symbol:    ps.Symbol,
+		Price:     ps.Price,
+		Change24h: ps.Change24h,
+		TakenAt:   ps.TakenAt,
+	}
+}
+
+type PriceCandle struct {
+	Symbol   string    `gorm:"column:symbol;primaryKey"`
+	Interval string    `gorm:"column:candle_interval;primaryKey"`
+	OpenTime time.Time `gorm:"column:open_time;primaryKey"`
+	Open     float64   `gorm:"column:open_price"`
+	High     float64   `gorm:"column:high_price"`
+	Low      float64   `gorm:"column:low_price"`
+	Close    float64   `gorm:"column:close_price"`
+}
+
+func (pc PriceCandle) TableName() string {
+	return "price_candle"
+}
+
+func (pc PriceCandle) ToModel() models.Candle {
+	return models.Candle{
+		Symbol:   pc.Symbol,
+		Interval: models.CandleInterval(pc.Interval),
+		OpenTime: pc.OpenTime,
+		Open:     pc.Open,
+		High:     pc.High,
+		Low:      pc.Low,
+		Close:    pc.Close,
 	}
 }
@@ -17,9 +17,26 @@ func toCurrenciesEntity(currencies []models.Currency) Currencies {
 			AddressValidation: currency.AddressValidation,
 			Popular:           currency.IsPopular(),
 			Networks: lo.Map(currency.GetNetworks(), func(network models.NetworkPair, _ int) CurrencyNetwork {
+				info := currency.GetNetworkInfo(network)
 				return CurrencyNetwork{
-					Symbol:  network.Symbol,
-					Network: network.Network,
+					Symbol:            network.Symbol,
+					Network:           network.Network,
+					ContractAddress:   lo.EmptyableToPtr(info.Contract),
+					HasExtraId:        info.HasExtraId,
+					ExtraIdValidation: info.ExtraIdValidation,
+					AddressExplorer:   info.AddressExplorer,
+					TxExplorer:        info.TxExplorer,
+					Exchanges: lo.Map(info.Exchanges, func(exchange string, _ int) CurrencyNetworkExchange {
+						warnings := info.GetWarnings(exchange)
+						return CurrencyNetworkExchange{
+							Symbol:       network.Symbol,
+							Network:      network.Network,
+							Exchange:     exchange,
+							Ticker:       info.GetTicker(exchange, network.Symbol),
+							WarningsFrom: warnings.From,
+							WarningsTo:   warnings.To,
+						}
+					}),
 				}
 			}),
 		}
@@ -35,6 +52,7 @@ func toPairSlice(currencies []models.NetworkPair) [][]string {
 func toSwapEntity(swap models.Swap) Swap {
 	return Swap{
 		Id:            swap.Id,
+		ParentId:      lo.EmptyableToPtr(swap.ParentId),
 		FromSymbol:    swap.From.Symbol,
 		FromNetwork:   swap.From.Network,
 		ToSymbol:      swap.To.Symbol,
@@ -42,13 +60,68 @@ func toSwapEntity(swap models.Swap) Swap {
 		PayinAmount:   swap.PayinAmount,
 		PayoutAmount:  swap.PayoutAmount,
 		PayoutAddress: swap.PayoutAddress,
+		PayoutExtraId: swap.PayoutExtraId,
 		ToAddress:     swap.ToAddress,
+		ToExtraId:     swap.ToExtraId,
 		RefundAddress: swap.RefundAddress,
+		RefundExtraId: swap.RefundExtraId,
 		Exchange:      swap.Exchange,
 		Status:        swap.Status,
 		CreatedAt:     swap.CreatedAt,
 		UpdatedAt:     swap.UpdatedAt,
 		ExchangeId:    swap.ExchangeId,
 		Reason:        swap.Reason,
+		RateId:        swap.RateId,
+		PayinHash:     swap.PayinHash,
+		PayoutHash:    swap.PayoutHash,
 	}
 }
+
+func toQuoteHistoryEntities(records []models.QuoteRecord) []QuoteHistory {
+	return lo.Map(records, func(record models.QuoteRecord, _ int) QuoteHistory {
+		return QuoteHistory{
+			FanOutId:      record.FanOutId,
+			Exchange:      record.Exchange,
+			FromSymbol:    record.From.Symbol,
+			FromNetwork:   record.From.Network,
+			ToSymbol:      record.To.Symbol,
+			ToNetwork:     record.To.Network,
+			RateMode:      string(record.RateMode),
+			AmountType:    string(record.AmountType),
+			FromAmount:    record.FromAmount,
+			Amount:        record.Amount,
+			Difference:    record.Difference,
+			LatencyMs:     record.Latency.Milliseconds(),
+			Status:        string(record.Status),
+			ErrorCategory: lo.EmptyableToPtr(record.ErrorCategory),
+			Won:           record.Won,
+		}
+	})
+}
+
+func toPriceSnapshotEntities(snapshots []models.PriceSnapshot) []PriceSnapshot {
+	return lo.Map(snapshots, func(snapshot models.PriceSnapshot, _ int) PriceSnapshot {
+		return PriceSnapshot{
+			Symbol:    snapshot.Symbol,
+			TakenAt:   snapshot.TakenAt,
+			Price:     snapshot.Price,
+			Change24h: snapshot.Change24h,
+		}
+	})
+}
+
+func toPriceCandleEntities(snapshots []models.PriceSnapshot) []PriceCandle {
+	return lo.FlatMap(snapshots, func(snapshot models.PriceSnapshot, _ int) []PriceCandle {
+		return lo.Map(snapshot.GetCandles(), func(candle models.Candle, _ int) PriceCandle {
+			return PriceCandle{
+				Symbol:   candle.Symbol,
+				Interval: string(candle.Interval),
+				OpenTime: candle.OpenTime,
+				Open:     candle.Open,
+				High:     candle.High,
+				Low:      candle.Low,
+				Close:    candle.Close,
+			}
+		})
+	})
+}
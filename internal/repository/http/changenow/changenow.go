@@ -8,27 +8,39 @@ import (
 	"cryptoswap/internal/services/interfaces"
 	"cryptoswap/internal/services/models"
 	"net/http"
+	"strconv"
 
 	"github.com/samber/lo"
 )
 
 const (
-	apiName = "ChangeNOW"
+	apiName       = "ChangeNOW"
+	flowStandard  = "standard"
+	flowFixedRate = "fixed-rate"
+	typeDirect    = "direct"
+	typeReverse   = "reverse"
 )
 
-var _ interfaces.CurrencyFetcher = &changeNowRepository{}
+var (
+	_ interfaces.CurrencyFetcher   = &changeNowRepository{}
+	_ interfaces.SwapCreator       = &changeNowRepository{}
+	_ interfaces.SwapStatusFetcher = &changeNowRepository{}
+	_ interfaces.LimitFetcher      = &changeNowRepository{}
+)
 
 func NewChangeNowRepository(logger logger.Logger,
 	factory httpclient.Factory) *changeNowRepository {
 	return &changeNowRepository{
-		logger:  logger,
-		factory: factory,
+		logger:   logger,
+		factory:  factory,
+		networks: newNetworkTranslator(),
 	}
 }
 
 type changeNowRepository struct {
-	logger  logger.Logger
-	factory httpclient.Factory
+	logger   logger.Logger
+	factory  httpclient.Factory
+	networks models.NetworkTranslator
 }
 
 func (cn *changeNowRepository) GetExchangeName() string {
@@ -44,11 +56,82 @@ func (cn *changeNowRepository) GetCurrencies(ctx context.Context) ([]models.Curr
 	}
 
 	return lo.Map(currencies, func(curr Currency, _ int) models.Currency {
-		return curr.ToModel(cn.GetExchangeName())
+		return curr.ToModel(cn.GetExchangeName(), cn.networks)
 	}), nil
 }
 
-func (cn *changeNowRepository) GetQuote(ctx context.Context, from, to models.NetworkPair,
-	amount float64) (models.Quote, *apierrors.ApiError) {
-	return models.Quote{}, nil
+// GetQuote answers reverse quotes only on fixed rates, ChangeNOW doesn't
+// estimate them on the standard flow.
+func (cn *changeNowRepository) GetQuote(ctx context.Context, quoteRequest models.QuoteRequest,
+) (models.Quote, *apierrors.ApiError) {
+	reverse := quoteRequest.AmountType.IsReverse()
+	if reverse && !quoteRequest.Rate.IsFixed() {
+		return models.Quote{}, nil
+	}
+
+	amount := strconv.FormatFloat(quoteRequest.Amount, 'f', -1, 64)
+	request := cn.factory.NewClient(ctx).
+		WithQueryParams("fromCurrency", quoteRequest.From.Symbol).
+		WithQueryParams("fromNetwork", cn.networks.ToProvider(quoteRequest.From)).
+		WithQueryParams("toCurrency", quoteRequest.To.Symbol).
+		WithQueryParams("toNetwork", cn.networks.ToProvider(quoteRequest.To)).
+		WithQueryParams(lo.Ternary(reverse, "toAmount", "fromAmount"), amount).
+		WithQueryParams("flow", toFlow(quoteRequest.Rate)).
+		WithQueryParams("type", lo.Ternary(reverse, typeReverse, typeDirect)).
+		WithQueryParams("useRateId", quoteRequest.Rate.IsFixed()).
+		Get
+
+	estimation, err := httpclient.HandleRequest[EstimatedAmount](request,
+		"/exchange/estimated-amount", http.StatusOK)
+	if err != nil {
+		return models.Quote{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return estimation.ToQuote(cn.GetExchangeName(), quoteRequest), nil
+}
+
+func (cn *changeNowRepository) GetLimits(ctx context.Context, from, to models.NetworkPair,
+	rate models.RateMode) (models.Limits, *apierrors.ApiError) {
+	request := cn.factory.NewClient(ctx).
+		WithQueryParams("fromCurrency", from.Symbol).
+		WithQueryParams("fromNetwork", cn.networks.ToProvider(from)).
+		WithQueryParams("toCurrency", to.Symbol).
+		WithQueryParams("toNetwork", cn.networks.ToProvider(to)).
+		WithQueryParams("flow", toFlow(rate)).
+		Get
+
+	rng, err := httpclient.HandleRequest[Range](request, "/exchange/range", http.StatusOK)
+	if err != nil {
+		return models.Limits{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return rng.ToLimits(), nil
+}
+
+func (cn *changeNowRepository) CreateSwap(ctx context.Context, swap models.Swap,
+) (models.ExchangeSwap, *apierrors.ApiError) {
+	request := cn.factory.NewClient(ctx).
+		WithBody(NewExchangePayload(swap, cn.networks)).
+		Post
+
+	exchange, err := httpclient.HandleRequest[Exchange](request, "/exchange", http.StatusOK)
+	if err != nil {
+		return models.ExchangeSwap{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return exchange.ToExchangeSwap(), nil
+}
+
+func (cn *changeNowRepository) GetSwapStatus(ctx context.Context, exchangeId string,
+) (models.ExchangeSwapStatus, *apierrors.ApiError) {
+	request := cn.factory.NewClient(ctx).
+		WithQueryParams("id", exchangeId).
+		Get
+
+	status, err := httpclient.HandleRequest[ExchangeStatus](request, "/exchange/by-id", http.StatusOK)
+	if err != nil {
+		return models.ExchangeSwapStatus{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return status.ToExchangeSwapStatus(), nil
 }
@@ -0,0 +1,240 @@
+package changenow
+
+import (
+	"context"
+	"cryptoswap/internal/lib/apierrors"
+	"cryptoswap/internal/lib/httpclient/httpclienttest"
+	"cryptoswap/internal/services/models"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newTestRepository(t *testing.T, routes ...httpclienttest.Route) (*changeNowRepository, *httpclienttest.Server) {
+	server := httpclienttest.NewServer(t, routes...)
+	return NewChangeNowRepository(httpclienttest.NewLogger("changenow"), server.Factory()), server
+}
+
+func TestGetCurrencies(t *testing.T) {
+	repository, server := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/currencies", http.StatusOK, "currencies.json").
+			WithQuery("active", "true"))
+
+	currencies, err := repository.GetCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(currencies) != 4 {
+		t.Fatalf("expected 4 currencies, got %d", len(currencies))
+	}
+
+	tests := []struct {
+		pair       models.NetworkPair
+		contract   string
+		hasExtraId bool
+	}{
+		{pair: models.NewNetworkPair("btc", "btc")},
+		{pair: models.NewNetworkPair("usdt", "eth"), contract: "0xdac17f958d2ee523a2206206994597c13d831ec7"},
+		{pair: models.NewNetworkPair("usdt", "bsc"), contract: "0x55d398326f99059ff775485246999027b3197955"},
+		{pair: models.NewNetworkPair("xrp", "xrp"), hasExtraId: true},
+	}
+	for i, tt := range tests {
+		currency := currencies[i]
+		networks := currency.GetNetworks()
+		if len(networks) != 1 || networks[0] != tt.pair {
+			t.Errorf("currency %d: expected network %s, got %v", i, tt.pair, networks)
+			continue
+		}
+
+		info := currency.GetNetworkInfo(tt.pair)
+		if info.Contract != tt.contract {
+			t.Errorf("%s: expected contract %q, got %q", tt.pair, tt.contract, info.Contract)
+		}
+		if info.HasExtraId != tt.hasExtraId {
+			t.Errorf("%s: expected extra id %t, got %t", tt.pair, tt.hasExtraId, info.HasExtraId)
+		}
+		if !info.IsSupportedBy(apiName) {
+			t.Errorf("%s: expected to be listed by %s, got %v", tt.pair, apiName, info.Exchanges)
+		}
+	}
+
+	if got := server.Requests()[0].Header.Get("Authorization"); got != "Bearer test-api-key" {
+		t.Errorf("expected bearer auth, got %q", got)
+	}
+}
+
+func TestGetCurrencies_ErrorStatus(t *testing.T) {
+	repository, _ := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/currencies", http.StatusInternalServerError,
+			"pair_inactive.json"))
+
+	_, err := repository.GetCurrencies(context.Background())
+	if err == nil || err.Code != apierrors.InternalServer.Code {
+		t.Fatalf("expected internal server error, got %v", err)
+	}
+}
+
+func TestGetQuote(t *testing.T) {
+	from := models.NewNetworkPair("btc", "btc")
+	to := models.NewNetworkPair("usdt", "bsc")
+
+	repository, server := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/estimated-amount", http.StatusOK,
+			"estimated_amount.json").WithQuery("flow", flowStandard),
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/estimated-amount", http.StatusOK,
+			"estimated_amount_fixed.json").WithQuery("flow", flowFixedRate))
+
+	quote, err := repository.GetQuote(context.Background(),
+		models.NewQuoteRequest(from, to, 0.1, models.RateFloating))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if quote.Amount != 6512.348712 || quote.Exchange != apiName || quote.From != from || quote.To != to {
+		t.Errorf("unexpected quote: %+v", quote)
+	}
+	if quote.RateId != "" || !quote.ValidUntil.IsZero() {
+		t.Errorf("expected a floating quote without rate, got %+v", quote)
+	}
+
+	query := server.Requests()[0].Query
+	want := map[string]string{
+		"fromCurrency": "btc",
+		"fromNetwork":  "btc",
+		"toCurrency":   "usdt",
+		"toNetwork":    "bsc",
+		"fromAmount":   "0.1",
+		"type":         typeDirect,
+		"useRateId":    "false",
+	}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("expected query %s=%s, got %q", key, value, got)
+		}
+	}
+}
+
+func TestGetQuote_FixedRate(t *testing.T) {
+	repository, server := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/estimated-amount", http.StatusOK,
+			"estimated_amount_fixed.json").WithQuery("flow", flowFixedRate))
+
+	quote, err := repository.GetQuote(context.Background(), models.NewQuoteRequest(
+		models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("usdt", "eth"), 0.1, models.RateFixed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	validUntil := time.Date(2025, 9, 1, 10, 15, 30, 0, time.UTC)
+	if quote.RateMode != models.RateFixed || quote.RateId != "Vp2RqHyUn1XqRzUkTg5cAMt8nSdvAqkP" ||
+		!quote.ValidUntil.Equal(validUntil) {
+		t.Errorf("unexpected fixed quote: %+v", quote)
+	}
+
+	if got := server.Requests()[0].Query.Get("useRateId"); got != "true" {
+		t.Errorf("expected useRateId=true, got %q", got)
+	}
+}
+
+func TestGetQuote_ErrorStatus(t *testing.T) {
+	repository, _ := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/estimated-amount", http.StatusBadRequest,
+			"pair_inactive.json"))
+
+	quote, err := repository.GetQuote(context.Background(), models.NewQuoteRequest(
+		models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("usdt", "eth"), 0.1, models.RateFloating))
+	if err == nil {
+		t.Fatalf("expected an error, got quote %+v", quote)
+	}
+	if !quote.IsEmpty() {
+		t.Errorf("expected an empty quote, got %+v", quote)
+	}
+}
+
+func TestGetLimits(t *testing.T) {
+	repository, _ := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/range", http.StatusOK, "range.json"))
+
+	limits, err := repository.GetLimits(context.Background(), models.NewNetworkPair("btc", "btc"),
+		models.NewNetworkPair("usdt", "eth"), models.RateFloating)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if limits.Min != 0.00032 || limits.Max != 0 {
+		t.Errorf("unexpected limits: %+v", limits)
+	}
+}
+
+func TestGetQuote_Reverse(t *testing.T) {
+	repository, server := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/estimated-amount", http.StatusOK,
+			"estimated_amount_reverse.json").WithQuery("type", typeReverse))
+
+	request := models.NewQuoteRequest(models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("usdt", "eth"),
+		10000, models.RateFixed).WithAmountType(models.AmountTo)
+	quote, err := repository.GetQuote(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if quote.FromAmount != 0.15432 || quote.Amount != 10000 || quote.AmountType != models.AmountTo {
+		t.Errorf("unexpected reverse quote: %+v", quote)
+	}
+
+	query := server.Requests()[0].Query
+	if query.Get("toAmount") != "10000" || query.Has("fromAmount") {
+		t.Errorf("expected the amount as toAmount, got %v", query)
+	}
+}
+
+func TestGetQuote_ReverseFloating(t *testing.T) {
+	repository, server := newTestRepository(t)
+
+	request := models.NewQuoteRequest(models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("usdt", "eth"),
+		10000, models.RateFloating).WithAmountType(models.AmountTo)
+	quote, err := repository.GetQuote(context.Background(), request)
+	if err != nil || !quote.IsEmpty() {
+		t.Fatalf("expected an empty quote, got %+v and error %v", quote, err)
+	}
+	if len(server.Requests()) != 0 {
+		t.Error("expected no request for a floating reverse quote")
+	}
+}
+
+func TestNetworkRoundTrip(t *testing.T) {
+	repository, server := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/currencies", http.StatusOK, "currencies.json"),
+		httpclienttest.NewRoute(http.MethodGet, "/exchange/estimated-amount", http.StatusOK,
+			"estimated_amount.json"))
+
+	currencies, err := repository.GetCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Every listed network goes back to ChangeNOW as it was listed
+	listed := map[models.NetworkPair]string{
+		models.NewNetworkPair("btc", "btc"):  "btc",
+		models.NewNetworkPair("usdt", "eth"): "eth",
+		models.NewNetworkPair("usdt", "bsc"): "BEP20",
+		models.NewNetworkPair("xrp", "xrp"):  "xrp",
+	}
+	for _, currency := range currencies {
+		for _, pair := range currency.GetNetworks() {
+			if got := repository.networks.ToProvider(pair); got != listed[pair] {
+				t.Errorf("%s: expected network %q, got %q", pair, listed[pair], got)
+			}
+		}
+	}
+
+	_, err = repository.GetQuote(context.Background(), models.NewQuoteRequest(
+		models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("usdt", "bsc"), 0.1, models.RateFloating))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := server.Requests()[1].Query.Get("toNetwork"); got != "BEP20" {
+		t.Errorf("expected toNetwork=BEP20, got %q", got)
+	}
+}
@@ -1,6 +1,19 @@
 package changenow
 
-import "cryptoswap/internal/services/models"
+import (
+	"cryptoswap/internal/lib/parser"
+	"cryptoswap/internal/services/models"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// newNetworkTranslator translates ChangeNOW network codes. They mostly are our
+// canonical ones already, the shared aliases cover the rest.
+func newNetworkTranslator() models.NetworkTranslator {
+	return models.NewNetworkTranslator(nil)
+}
 
 type Currency struct {
 	Ticker             string `json:"ticker"`
@@ -20,6 +33,131 @@ type Currency struct {
 	HasExternalId      bool   `json:"hasExternalId"`
 }
 
-func (c Currency) ToModel(provider string) models.Currency {
-	return models.NewCurrency(provider, c.Network, c.Ticker, c.Name, "", c.Image, c.IsAvailable)
+func (c Currency) ToModel(provider string, networks models.NetworkTranslator) models.Currency {
+	network := networks.ToCanonical(c.Ticker, c.Network)
+	return models.NewCurrency(provider, network, c.Ticker, c.Name, "", c.Image, c.IsAvailable).
+		WithNetworkInfo(models.NetworkInfo{
+			Contract:   c.TokenContract,
+			HasExtraId: c.IsExtraIdSupported,
+		})
+}
+
+type EstimatedAmount struct {
+	FromCurrency             string     `json:"fromCurrency"`
+	FromNetwork              string     `json:"fromNetwork"`
+	ToCurrency               string     `json:"toCurrency"`
+	ToNetwork                string     `json:"toNetwork"`
+	Flow                     string     `json:"flow"`
+	Type                     string     `json:"type"`
+	RateId                   *string    `json:"rateId"`
+	ValidUntil               *time.Time `json:"validUntil"`
+	TransactionSpeedForecast *string    `json:"transactionSpeedForecast"`
+	WarningMessage           *string    `json:"warningMessage"`
+	FromAmount               float64    `json:"fromAmount"`
+	ToAmount                 float64    `json:"toAmount"`
+}
+
+func (e EstimatedAmount) ToQuote(provider string, request models.QuoteRequest) models.Quote {
+	quote := request.NewQuote(provider, lo.Ternary(request.AmountType.IsReverse(), e.FromAmount, e.ToAmount))
+	if e.RateId == nil {
+		return quote
+	}
+	return quote.WithRate(*e.RateId, lo.FromPtr(e.ValidUntil))
+}
+
+func NewExchangePayload(swap models.Swap, networks models.NetworkTranslator) ExchangePayload {
+	return ExchangePayload{
+		FromCurrency:  swap.From.Symbol,
+		FromNetwork:   networks.ToProvider(swap.From),
+		ToCurrency:    swap.To.Symbol,
+		ToNetwork:     networks.ToProvider(swap.To),
+		FromAmount:    strconv.FormatFloat(swap.PayinAmount, 'f', -1, 64),
+		Address:       swap.ToAddress,
+		ExtraId:       swap.ToExtraId,
+		RefundAddress: swap.RefundAddress,
+		RefundExtraId: swap.RefundExtraId,
+		Flow:          toFlow(swap.GetRateMode()),
+		Type:          typeDirect,
+		RateId:        swap.RateId,
+	}
+}
+
+func toFlow(rate models.RateMode) string {
+	if rate.IsFixed() {
+		return flowFixedRate
+	}
+	return flowStandard
+}
+
+type ExchangePayload struct {
+	FromCurrency  string `json:"fromCurrency"`
+	FromNetwork   string `json:"fromNetwork"`
+	ToCurrency    string `json:"toCurrency"`
+	ToNetwork     string `json:"toNetwork"`
+	FromAmount    string `json:"fromAmount"`
+	Address       string `json:"address"`
+	ExtraId       string `json:"extraId,omitempty"`
+	RefundAddress string `json:"refundAddress,omitempty"`
+	RefundExtraId string `json:"refundExtraId,omitempty"`
+	Flow          string `json:"flow"`
+	Type          string `json:"type"`
+	RateId        string `json:"rateId,omitempty"`
+}
+
+type Exchange struct {
+	Id            string  `json:"id"`
+	FromAmount    float64 `json:"fromAmount"`
+	ToAmount      float64 `json:"toAmount"`
+	PayinAddress  string  `json:"payinAddress"`
+	PayoutAddress string  `json:"payoutAddress"`
+	PayinExtraId  string  `json:"payinExtraId"`
+	RefundAddress string  `json:"refundAddress"`
+}
+
+func (e Exchange) ToExchangeSwap() models.ExchangeSwap {
+	return models.ExchangeSwap{
+		ExchangeId:     e.Id,
+		DepositAddress: e.PayinAddress,
+		DepositExtraId: e.PayinExtraId,
+		PayinAmount:    e.FromAmount,
+		PayoutAmount:   e.ToAmount,
+	}
+}
+
+// exchangeStatuses maps ChangeNOW transaction statuses to ours.
+var exchangeStatuses = map[string]string{
+	"new":        models.SwapStatusPending,
+	"waiting":    models.SwapStatusPending,
+	"confirming": models.SwapStatusConfirming,
+	"verifying":  models.SwapStatusConfirming,
+	"exchanging": models.SwapStatusExchanging,
+	"sending":    models.SwapStatusSending,
+	"finished":   models.SwapStatusCompleted,
+	"failed":     models.SwapStatusFailed,
+	"refunded":   models.SwapStatusRefunded,
+	"expired":    models.SwapStatusExpired,
+}
+
+type ExchangeStatus struct {
+	Id         string `json:"id"`
+	Status     string `json:"status"`
+	PayinHash  string `json:"payinHash"`
+	PayoutHash string `json:"payoutHash"`
+}
+
+func (e ExchangeStatus) ToExchangeSwapStatus() models.ExchangeSwapStatus {
+	return models.NewExchangeSwapStatus(e.Status, exchangeStatuses).
+		WithHashes(e.PayinHash, e.PayoutHash)
+}
+
+type Range struct {
+	MinAmount parser.Float64 `json:"minAmount"`
+	MaxAmount parser.Float64 `json:"maxAmount"`
+}
+
+func (r Range) ToLimits() models.Limits {
+	return models.Limits{
+		Min: r.MinAmount.Value(),
+		Max: r.MaxAmount.Value(),
+	}
 }
@@ -0,0 +1,65 @@
+package coingecko
+
+import (
+	"context"
+	"cryptoswap/internal/lib/httpclient"
+	"cryptoswap/internal/lib/httpclient/httpclienttest"
+	"cryptoswap/internal/services/models"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func newTestRepository(t *testing.T, routes ...httpclienttest.Route) (*coinGecko, *httpclienttest.Server) {
+	server := httpclienttest.NewServer(t, routes...)
+	return NewCoinGecko(httpclienttest.NewLogger("coingecko"), server.Factory()), server
+}
+
+func TestTopTickers(t *testing.T) {
+	repository, server := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/coins/markets", http.StatusOK, "markets.json"))
+
+	tickers, err := repository.TopTickers(context.Background(), "usd", 3, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []models.Ticker{
+		{Name: "Bitcoin", Symbol: "BTC", Price: 64123.45, Change: 1.73521},
+		{Name: "Ethereum", Symbol: "ETH", Price: 2518.07, Change: -2.04817},
+		{Name: "Tether", Symbol: "USDT", Price: 1.0, Change: 0.01234},
+	}
+	if len(tickers) != len(want) {
+		t.Fatalf("expected %d tickers, got %d", len(want), len(tickers))
+	}
+	for i := range want {
+		if tickers[i] != want[i] {
+			t.Errorf("ticker %d: expected %+v, got %+v", i, want[i], tickers[i])
+		}
+	}
+
+	query := server.Requests()[0].Query
+	wantQuery := map[string]string{
+		"vs_currency":             "usd",
+		"order":                   "market_cap_desc",
+		"per_page":                "3",
+		"page":                    "2",
+		"price_change_percentage": "24h",
+	}
+	for key, value := range wantQuery {
+		if got := query.Get(key); got != value {
+			t.Errorf("expected query %s=%s, got %q", key, value, got)
+		}
+	}
+}
+
+func TestTopTickers_RateLimited(t *testing.T) {
+	repository, _ := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/coins/markets", http.StatusTooManyRequests, "rate_limited.json"))
+
+	tickers, err := repository.TopTickers(context.Background(), "usd", 3, 1)
+	statusErr := &httpclient.StatusError{}
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected a 429 status error, got tickers %+v and error %v", tickers, err)
+	}
+}
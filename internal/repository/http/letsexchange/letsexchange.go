@@ -0,0 +1,189 @@
+package letsexchange
+
+import (
+	"context"
+	"cryptoswap/internal/lib/apierrors"
+	"cryptoswap/internal/lib/httpclient"
+	"cryptoswap/internal/lib/logger"
+	"cryptoswap/internal/services/interfaces"
+	"cryptoswap/internal/services/models"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/samber/lo"
+)
+
+const (
+	apiName            = "LetsExchange"
+	infoEndpoint       = "/v1/info"
+	infoRevertEndpoint = "/v1/info-revert"
+)
+
+var (
+	_ interfaces.CurrencyFetcher   = &letsExchangeRepository{}
+	_ interfaces.SwapStatusFetcher = &letsExchangeRepository{}
+	_ interfaces.QuoteLimiter      = &letsExchangeRepository{}
+)
+
+func NewLetsExchangeRepository(logger logger.Logger,
+	factory httpclient.Factory) *letsExchangeRepository {
+	return &letsExchangeRepository{
+		logger:          logger,
+		factory:         factory,
+		networks:        newNetworkTranslator(),
+		networkCodes:    make(map[models.NetworkPair]string),
+		defaultNetworks: make(map[string]string),
+	}
+}
+
+type letsExchangeRepository struct {
+	logger   logger.Logger
+	factory  httpclient.Factory
+	networks models.NetworkTranslator
+
+	// LetsExchange expects its own network codes (e.g. TRC20), so the ones
+	// seen in /v2/coins are cached to translate our lowercased pairs back.
+	mu              sync.RWMutex
+	networkCodes    map[models.NetworkPair]string
+	defaultNetworks map[string]string
+}
+
+func (le *letsExchangeRepository) GetExchangeName() string {
+	return apiName
+}
+
+func (le *letsExchangeRepository) GetCurrencies(ctx context.Context) ([]models.Currency, *apierrors.ApiError) {
+	coins, err := le.fetchCoins(ctx)
+	if err != nil {
+		return []models.Currency{}, err
+	}
+
+	return lo.FlatMap(coins, func(coin Coin, _ int) []models.Currency {
+		return coin.ToModels(le.GetExchangeName(), le.networks)
+	}), nil
+}
+
+func (le *letsExchangeRepository) GetQuote(ctx context.Context, quoteRequest models.QuoteRequest,
+) (models.Quote, *apierrors.ApiError) {
+	endpoint := lo.Ternary(quoteRequest.AmountType.IsReverse(), infoRevertEndpoint, infoEndpoint)
+	info, err := le.getInfo(ctx, endpoint, quoteRequest.From, quoteRequest.To, quoteRequest.Amount,
+		quoteRequest.Rate)
+	if err != nil {
+		return models.Quote{}, err
+	}
+
+	return info.ToQuote(le.GetExchangeName(), quoteRequest), nil
+}
+
+// HasQuoteLimits tells the quotes already carry the range of the pair.
+func (le *letsExchangeRepository) HasQuoteLimits() bool {
+	return true
+}
+
+// GetLimits asks /v1/info for any amount, the range comes back regardless of it.
+func (le *letsExchangeRepository) GetLimits(ctx context.Context, from, to models.NetworkPair,
+	rate models.RateMode) (models.Limits, *apierrors.ApiError) {
+	info, err := le.getInfo(ctx, infoEndpoint, from, to, 1, rate)
+	if err != nil {
+		return models.Limits{}, err
+	}
+
+	return info.ToLimits(), nil
+}
+
+// getInfo estimates the amount received on /v1/info, or the amount to send for
+// receiving amount on /v1/info-revert.
+func (le *letsExchangeRepository) getInfo(ctx context.Context, endpoint string, from, to models.NetworkPair,
+	amount float64, rate models.RateMode) (InfoResponse, *apierrors.ApiError) {
+	if err := le.ensureNetworksLoaded(ctx); err != nil {
+		le.logger.Warningf(ctx, "Could not load networks, using fallback codes: %v", err)
+	}
+
+	payload := NewInfoPayload(from, to, le.networkCode(from), le.networkCode(to), amount, rate)
+	request := le.factory.NewClient(ctx).
+		WithBody(payload).
+		WithIdempotent().
+		Post
+
+	info, err := httpclient.HandleRequest[InfoResponse](request, endpoint, http.StatusOK)
+	if err != nil {
+		return InfoResponse{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return info, nil
+}
+
+func (le *letsExchangeRepository) GetSwapStatus(ctx context.Context, exchangeId string,
+) (models.ExchangeSwapStatus, *apierrors.ApiError) {
+	request := le.factory.NewClient(ctx).Get
+
+	transaction, err := httpclient.HandleRequest[Transaction](request,
+		"/v1/transaction/"+url.PathEscape(exchangeId), http.StatusOK)
+	if err != nil {
+		return models.ExchangeSwapStatus{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return transaction.ToExchangeSwapStatus(), nil
+}
+
+func (le *letsExchangeRepository) fetchCoins(ctx context.Context) ([]Coin, *apierrors.ApiError) {
+	request := le.factory.NewClient(ctx).Get
+
+	coins, err := httpclient.HandleRequest[[]Coin](request, "/v2/coins", http.StatusOK)
+	if err != nil {
+		return nil, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	le.storeNetworks(coins)
+	return coins, nil
+}
+
+func (le *letsExchangeRepository) storeNetworks(coins []Coin) {
+	networkCodes := make(map[models.NetworkPair]string)
+	defaultNetworks := make(map[string]string, len(coins))
+	for _, coin := range coins {
+		for _, network := range coin.Networks {
+			pair := models.NewNetworkPair(coin.Code, network.ToCanonical(coin.Code, le.networks))
+			networkCodes[pair] = network.Code
+		}
+		defaultNetworks[strings.ToLower(coin.Code)] = coin.GetDefaultNetwork()
+	}
+
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	le.networkCodes = networkCodes
+	le.defaultNetworks = defaultNetworks
+}
+
+func (le *letsExchangeRepository) ensureNetworksLoaded(ctx context.Context) *apierrors.ApiError {
+	le.mu.RLock()
+	loaded := len(le.networkCodes) > 0
+	le.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	_, err := le.fetchCoins(ctx)
+	return err
+}
+
+// networkCode translates a canonical pair into the network code LetsExchange
+// knows. Native coins resolve to the coin's default network.
+func (le *letsExchangeRepository) networkCode(pair models.NetworkPair) string {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+
+	if code, ok := le.networkCodes[pair]; ok {
+		return code
+	}
+
+	if pair.Network == models.NativeNetwork(pair.Symbol) {
+		if code, ok := le.defaultNetworks[pair.Symbol]; ok {
+			return code
+		}
+	}
+
+	return strings.ToUpper(le.networks.ToProvider(pair))
+}
@@ -0,0 +1,76 @@
+package letsexchange
+
+import (
+	"context"
+	"cryptoswap/internal/lib/httpclient/httpclienttest"
+	"cryptoswap/internal/services/models"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func newTestRepository(t *testing.T, routes ...httpclienttest.Route) (*letsExchangeRepository, *httpclienttest.Server) {
+	server := httpclienttest.NewServer(t, routes...)
+	return NewLetsExchangeRepository(httpclienttest.NewLogger("letsexchange"), server.Factory()), server
+}
+
+var coinsRoute = httpclienttest.NewRoute(http.MethodGet, "/v2/coins", http.StatusOK, "coins.json")
+
+func TestGetQuote(t *testing.T) {
+	repository, server := newTestRepository(t, coinsRoute,
+		httpclienttest.NewRoute(http.MethodPost, infoEndpoint, http.StatusOK, "info.json"))
+
+	quote, err := repository.GetQuote(context.Background(), models.NewQuoteRequest(
+		models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("usdt", "trx"), 0.1, models.RateFloating))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The limits come along with the estimation
+	if quote.Amount != 6481.107 || quote.Limits != (models.Limits{Min: 0.0008, Max: 12.5}) || !quote.WithinLimits {
+		t.Errorf("unexpected quote: %+v", quote)
+	}
+	if !repository.HasQuoteLimits() {
+		t.Error("expected the quotes to carry the limits")
+	}
+
+	payload := InfoPayload{}
+	if err := json.Unmarshal(server.Requests()[1].Body, &payload); err != nil {
+		t.Fatalf("unexpected payload: %v", err)
+	}
+	want := InfoPayload{From: "BTC", To: "USDT", NetworkFrom: "BTC", NetworkTo: "TRC20", Amount: 0.1, Float: true}
+	if payload != want {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestNetworkRoundTrip(t *testing.T) {
+	repository, _ := newTestRepository(t, coinsRoute)
+
+	currencies, err := repository.GetCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Every listed network goes back to LetsExchange as it was listed
+	listed := map[models.NetworkPair]string{
+		models.NewNetworkPair("btc", "btc"):    "BTC",
+		models.NewNetworkPair("usdt", "eth"):   "ERC20",
+		models.NewNetworkPair("usdt", "trx"):   "TRC20",
+		models.NewNetworkPair("usdt", "matic"): "POLYGON",
+		models.NewNetworkPair("xrp", "xrp"):    "XRP",
+	}
+	if len(currencies) != len(listed) {
+		t.Fatalf("expected a currency per network, got %d", len(currencies))
+	}
+	for _, currency := range currencies {
+		for _, pair := range currency.GetNetworks() {
+			if got := repository.networkCode(pair); got != listed[pair] {
+				t.Errorf("%s: expected network %q, got %q", pair, listed[pair], got)
+			}
+			if got := repository.networks.ToProvider(pair); got != listed[pair] {
+				t.Errorf("%s: expected the translator to give %q, got %q", pair, listed[pair], got)
+			}
+		}
+	}
+}
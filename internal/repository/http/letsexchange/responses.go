@@ -0,0 +1,152 @@
+package letsexchange
+
+import (
+	"cryptoswap/internal/lib/parser"
+	"cryptoswap/internal/services/models"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// newNetworkTranslator translates LetsExchange network codes, named after the
+// token standard on the chains that have one.
+func newNetworkTranslator() models.NetworkTranslator {
+	return models.NewNetworkTranslator(map[string]string{
+		"ERC20": "eth",
+		"BEP20": "bsc",
+		"TRC20": "trx",
+		"BEP2":  "bnb",
+	})
+}
+
+type Coin struct {
+	Code               string    `json:"code"`
+	Name               string    `json:"name"`
+	Icon               string    `json:"icon"`
+	IsActive           int       `json:"is_active"`
+	DefaultNetworkCode string    `json:"default_network_code"`
+	Networks           []Network `json:"networks"`
+}
+
+type Network struct {
+	Name            string `json:"name"`
+	Code            string `json:"code"`
+	IsActive        int    `json:"is_active"`
+	HasExtra        int    `json:"has_extra"`
+	ValidationExtra string `json:"validation_extra"`
+}
+
+func (n Network) ToCanonical(symbol string, networks models.NetworkTranslator) string {
+	return networks.ToCanonical(symbol, n.Code)
+}
+
+func (c Coin) ToModels(provider string, networks models.NetworkTranslator) []models.Currency {
+	return lo.Map(c.Networks, func(network Network, _ int) models.Currency {
+		available := c.IsActive == 1 && network.IsActive == 1
+		return models.NewCurrency(provider, network.ToCanonical(c.Code, networks), c.Code, c.GetName(), "", c.Icon, available).
+			WithNetworkInfo(models.NetworkInfo{
+				HasExtraId:        network.HasExtra == 1,
+				ExtraIdValidation: network.ValidationExtra,
+			})
+	})
+}
+
+func (c Coin) GetName() string {
+	if c.Name == "" {
+		return c.Code
+	}
+	return c.Name
+}
+
+// GetDefaultNetwork returns the network LetsExchange uses for the coin when
+// none is given, falling back to the first active one and then to the code.
+func (c Coin) GetDefaultNetwork() string {
+	if c.DefaultNetworkCode != "" {
+		return c.DefaultNetworkCode
+	}
+
+	if network, ok := lo.Find(c.Networks, func(network Network) bool {
+		return network.IsActive == 1
+	}); ok {
+		return network.Code
+	}
+
+	return strings.ToUpper(c.Code)
+}
+
+func NewInfoPayload(from, to models.NetworkPair, networkFrom, networkTo string, amount float64,
+	rate models.RateMode) InfoPayload {
+	return InfoPayload{
+		From:        strings.ToUpper(from.Symbol),
+		To:          strings.ToUpper(to.Symbol),
+		NetworkFrom: networkFrom,
+		NetworkTo:   networkTo,
+		Amount:      amount,
+		Float:       !rate.IsFixed(),
+	}
+}
+
+type InfoPayload struct {
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+	NetworkFrom string  `json:"network_from"`
+	NetworkTo   string  `json:"network_to"`
+	Amount      float64 `json:"amount"`
+	Float       bool    `json:"float"`
+}
+
+type InfoResponse struct {
+	MinAmount     parser.Float64 `json:"min_amount"`
+	MaxAmount     parser.Float64 `json:"max_amount"`
+	Amount        parser.Float64 `json:"amount"`
+	Fee           parser.Float64 `json:"fee"`
+	Rate          parser.Float64 `json:"rate"`
+	WithdrawalFee parser.Float64 `json:"withdrawal_fee"`
+	RateId        string         `json:"rate_id"`
+}
+
+func (i InfoResponse) ToLimits() models.Limits {
+	return models.Limits{
+		Min: i.MinAmount.Value(),
+		Max: i.MaxAmount.Value(),
+	}
+}
+
+// ToQuote keeps the rate id only for fixed requests, LetsExchange doesn't
+// tell how long it stays valid. The limits come along with the estimation.
+func (i InfoResponse) ToQuote(provider string, request models.QuoteRequest) models.Quote {
+	quote := request.NewQuote(provider, i.Amount.Value())
+	quote = quote.WithLimits(i.ToLimits(), quote.FromAmount)
+	if !request.Rate.IsFixed() || i.RateId == "" {
+		return quote
+	}
+	return quote.WithRate(i.RateId, time.Time{})
+}
+
+// transactionStatuses maps LetsExchange transaction statuses to ours.
+var transactionStatuses = map[string]string{
+	"wait":                 models.SwapStatusPending,
+	"confirmation":         models.SwapStatusConfirming,
+	"confirmed":            models.SwapStatusConfirming,
+	"exchanging":           models.SwapStatusExchanging,
+	"sending":              models.SwapStatusSending,
+	"sending_confirmation": models.SwapStatusSending,
+	"success":              models.SwapStatusCompleted,
+	"overdue":              models.SwapStatusExpired,
+	"refund":               models.SwapStatusRefunded,
+	"error":                models.SwapStatusFailed,
+	"aml_check_failed":     models.SwapStatusFailed,
+}
+
+type Transaction struct {
+	TransactionId string `json:"transaction_id"`
+	Status        string `json:"status"`
+	HashIn        string `json:"hash_in"`
+	HashOut       string `json:"hash_out"`
+}
+
+func (t Transaction) ToExchangeSwapStatus() models.ExchangeSwapStatus {
+	return models.NewExchangeSwapStatus(t.Status, transactionStatuses).
+		WithHashes(t.HashIn, t.HashOut)
+}
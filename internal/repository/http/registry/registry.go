@@ -0,0 +1,143 @@
+// Package registry builds the exchange adapters enabled in the config.
+package registry
+
+import (
+	"cryptoswap/internal/config"
+	"cryptoswap/internal/lib/breaker"
+	"cryptoswap/internal/lib/httpclient"
+	"cryptoswap/internal/lib/logger"
+	"cryptoswap/internal/repository/http/changenow"
+	"cryptoswap/internal/repository/http/coingecko"
+	"cryptoswap/internal/repository/http/letsexchange"
+	"cryptoswap/internal/repository/http/simpleswap"
+	"cryptoswap/internal/repository/http/stealthex"
+	"cryptoswap/internal/services/interfaces"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+const cashFetcherName = "coingecko"
+
+type fetcherConstructor func(logger logger.Logger, factory httpclient.Factory) interfaces.CurrencyFetcher
+
+// fetchers are the exchange adapters that can be enabled, by config name.
+var fetchers = map[string]fetcherConstructor{
+	"change_now": func(logger logger.Logger, factory httpclient.Factory) interfaces.CurrencyFetcher {
+		return changenow.NewChangeNowRepository(logger, factory)
+	},
+	"stealthex": func(logger logger.Logger, factory httpclient.Factory) interfaces.CurrencyFetcher {
+		return stealthex.NewStealthExRepository(logger, factory)
+	},
+	"simpleswap": func(logger logger.Logger, factory httpclient.Factory) interfaces.CurrencyFetcher {
+		return simpleswap.NewSimpleSwapRepository(logger, factory)
+	},
+	"letsexchange": func(logger logger.Logger, factory httpclient.Factory) interfaces.CurrencyFetcher {
+		return letsexchange.NewLetsExchangeRepository(logger, factory)
+	},
+}
+
+// NewRegistry builds every enabled adapter. Unknown adapter names are rejected
+// so a typo in the config doesn't silently disable an exchange.
+func NewRegistry(loggerFactory logger.LoggerFactory, exchanges config.Exchanges) (*Registry, error) {
+	registry := &Registry{
+		weights:  map[string]float64{},
+		breakers: map[string]*breaker.Breaker{},
+	}
+
+	names := lo.Keys(exchanges)
+	sort.Strings(names)
+	for _, name := range names {
+		exchange := exchanges[name]
+		constructor, ok := fetchers[name]
+		if !ok && name != cashFetcherName {
+			return nil, fmt.Errorf("unknown exchange %q", name)
+		}
+		enabled, err := exchange.IsEnabled()
+		if err != nil {
+			return nil, fmt.Errorf("exchange %q: %w", name, err)
+		}
+		if !enabled {
+			continue
+		}
+		if exchange.BaseURL == "" {
+			return nil, fmt.Errorf("exchange %q has no base_url", name)
+		}
+
+		factory := httpclient.NewFactory(newHttpConfig(exchange), loggerFactory.NewLogger("http_client"))
+		if name == cashFetcherName {
+			registry.cashFetcher = coingecko.NewCoinGecko(loggerFactory.NewLogger(name), factory)
+			continue
+		}
+
+		fetcher := constructor(loggerFactory.NewLogger(name), factory)
+		registry.fetchers = append(registry.fetchers, fetcher)
+		registry.weights[fetcher.GetExchangeName()] = exchange.GetWeight()
+		registry.breakers[fetcher.GetExchangeName()] = newBreaker(exchange)
+	}
+
+	if registry.cashFetcher == nil {
+		return nil, fmt.Errorf("exchange %q must be enabled to fetch prices", cashFetcherName)
+	}
+	if len(registry.fetchers) == 0 {
+		return nil, fmt.Errorf("no exchanges enabled")
+	}
+
+	return registry, nil
+}
+
+func newHttpConfig(exchange config.Exchange) httpclient.HttpConfig {
+	return httpclient.HttpConfig{
+		BaseURL:    exchange.BaseURL,
+		ApiKey:     exchange.ApiKey,
+		AuthScheme: exchange.AuthScheme,
+		AuthHeader: exchange.AuthHeader,
+		AuthQuery:  exchange.AuthQuery,
+		Timeout:    exchange.GetTimeout(),
+		Retry: httpclient.RetryPolicy{
+			MaxAttempts:    exchange.Retry.MaxAttempts,
+			InitialBackoff: time.Duration(exchange.Retry.InitialBackoffMs) * time.Millisecond,
+			MaxBackoff:     time.Duration(exchange.Retry.MaxBackoffMs) * time.Millisecond,
+			MaxElapsed:     time.Duration(exchange.Retry.MaxElapsedSeconds) * time.Second,
+		},
+	}
+}
+
+func newBreaker(exchange config.Exchange) *breaker.Breaker {
+	return breaker.NewBreaker(breaker.Config{
+		FailureThreshold: exchange.CircuitBreaker.GetFailureThreshold(),
+		OpenTimeout:      exchange.CircuitBreaker.GetOpenTimeout(),
+		HalfOpenCalls:    exchange.CircuitBreaker.GetHalfOpenCalls(),
+		MaxConcurrent:    exchange.MaxConcurrent,
+	})
+}
+
+// Registry holds the enabled adapters.
+type Registry struct {
+	fetchers    []interfaces.CurrencyFetcher
+	cashFetcher interfaces.CashFetcher
+	weights     map[string]float64
+	breakers    map[string]*breaker.Breaker
+}
+
+func (r *Registry) GetCurrencyFetchers() []interfaces.CurrencyFetcher {
+	return r.fetchers
+}
+
+func (r *Registry) GetCashFetcher() interfaces.CashFetcher {
+	return r.cashFetcher
+}
+
+// GetWeights returns the ranking weight of each enabled exchange, by exchange
+// name.
+func (r *Registry) GetWeights() map[string]float64 {
+	return r.weights
+}
+
+// GetBreakers returns the circuit breaker of each enabled exchange, by
+// exchange name.
+func (r *Registry) GetBreakers() map[string]*breaker.Breaker {
+	return r.breakers
+}
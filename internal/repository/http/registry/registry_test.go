@@ -0,0 +1,80 @@
+package registry
+
+import (
+	"cryptoswap/internal/config"
+	"cryptoswap/internal/lib/logger"
+	"cryptoswap/internal/services/interfaces"
+	"slices"
+	"testing"
+
+	"github.com/samber/lo"
+)
+
+func newExchange(enabled string, weight float64) config.Exchange {
+	return config.Exchange{
+		Enabled: enabled,
+		BaseURL: "http://localhost",
+		Weight:  weight,
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	registry, err := NewRegistry(logger.NewLoggerFactory("test", "error"), config.Exchanges{
+		"change_now":   newExchange("true", 0),
+		"stealthex":    newExchange("true", 0.9),
+		"simpleswap":   newExchange("false", 0),
+		"letsexchange": newExchange("", 0),
+		"coingecko":    newExchange("true", 0),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fetchers := registry.GetCurrencyFetchers()
+	names := lo.Map(fetchers, func(fetcher interfaces.CurrencyFetcher, _ int) string {
+		return fetcher.GetExchangeName()
+	})
+	// An exchange without the enabled key is enabled
+	if !slices.Equal(names, []string{"ChangeNOW", "LetsExchange", "StealthEX"}) {
+		t.Fatalf("expected ChangeNOW, LetsExchange and StealthEX, got %v", names)
+	}
+
+	weights := registry.GetWeights()
+	if weights["ChangeNOW"] != 1 || weights["StealthEX"] != 0.9 {
+		t.Errorf("unexpected weights %v", weights)
+	}
+	if registry.GetCashFetcher() == nil {
+		t.Error("expected a cash fetcher")
+	}
+}
+
+func TestNewRegistry_InvalidConfig(t *testing.T) {
+	tests := map[string]config.Exchanges{
+		"unknown exchange": {
+			"changenow": newExchange("true", 0),
+			"coingecko": newExchange("true", 0),
+		},
+		"missing base url": {
+			"change_now": {Enabled: "true"},
+			"coingecko":  newExchange("true", 0),
+		},
+		"invalid enabled value": {
+			"change_now": newExchange("yes please", 0),
+			"coingecko":  newExchange("true", 0),
+		},
+		"no cash fetcher": {
+			"change_now": newExchange("true", 0),
+		},
+		"no exchanges": {
+			"change_now": newExchange("false", 0),
+			"coingecko":  newExchange("true", 0),
+		},
+	}
+	for name, exchanges := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRegistry(logger.NewLoggerFactory("test", "error"), exchanges); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
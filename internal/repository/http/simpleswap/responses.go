@@ -0,0 +1,156 @@
+package simpleswap
+
+import (
+	"cryptoswap/internal/lib/parser"
+	"cryptoswap/internal/services/models"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+// newNetworkTranslator translates SimpleSwap network codes to our canonical
+// ones.
+func newNetworkTranslator() models.NetworkTranslator {
+	return models.NewNetworkTranslator(nil)
+}
+
+type Currency struct {
+	Name              string   `json:"name"`
+	Symbol            string   `json:"symbol"`
+	Network           string   `json:"network"`
+	HasExtraId        bool     `json:"has_extra_id"`
+	ExtraId           string   `json:"extra_id"`
+	Image             string   `json:"image"`
+	WarningsFrom      []string `json:"warnings_from"`
+	WarningsTo        []string `json:"warnings_to"`
+	ValidationAddress string   `json:"validation_address"`
+	ValidationExtra   *string  `json:"validation_extra"`
+	AddressExplorer   string   `json:"address_explorer"`
+	TxExplorer        string   `json:"tx_explorer"`
+	IsFiat            bool     `json:"isFiat"`
+}
+
+// tokenStandards are the networks SimpleSwap appends to the tickers of the
+// tokens issued on them (usdterc20). Tickers on the other networks are the
+// coin's own, even when they end like the network (weth, msol).
+var tokenStandards = map[string]bool{
+	"erc20": true,
+	"bep20": true,
+	"bep2":  true,
+	"trc20": true,
+	"trc10": true,
+	"spl":   true,
+}
+
+// GetSymbol returns the coin symbol without the token standard SimpleSwap
+// appends to it.
+func (c Currency) GetSymbol() string {
+	symbol, network := strings.ToLower(c.Symbol), strings.ToLower(c.Network)
+	if !tokenStandards[network] {
+		return symbol
+	}
+	if base := strings.TrimSuffix(symbol, network); base != "" {
+		return base
+	}
+	return symbol
+}
+
+func (c Currency) ToNetworkPair(networks models.NetworkTranslator) models.NetworkPair {
+	symbol := c.GetSymbol()
+	return models.NewNetworkPair(symbol, networks.ToCanonical(symbol, c.Network))
+}
+
+func (c Currency) ToModel(provider string, networks models.NetworkTranslator) models.Currency {
+	pair := c.ToNetworkPair(networks)
+	return models.NewCurrency(provider, pair.Network, pair.Symbol, c.Name, c.ValidationAddress, c.Image, true).
+		WithNetworkInfo(models.NetworkInfo{
+			HasExtraId:        c.HasExtraId,
+			ExtraIdValidation: lo.FromPtr(c.ValidationExtra),
+			AddressExplorer:   c.AddressExplorer,
+			TxExplorer:        c.TxExplorer,
+		}.WithWarnings(provider, models.Warnings{From: c.WarningsFrom, To: c.WarningsTo}))
+}
+
+// Estimation is the estimated amount to receive, answered as a JSON string.
+type Estimation struct {
+	parser.Float64
+}
+
+func (e Estimation) ToQuote(provider string, request models.QuoteRequest) models.Quote {
+	return request.NewQuote(provider, e.Value())
+}
+
+type Ranges struct {
+	Min parser.Float64 `json:"min"`
+	Max parser.Float64 `json:"max"`
+}
+
+func (r Ranges) ToLimits() models.Limits {
+	return models.Limits{
+		Min: r.Min.Value(),
+		Max: r.Max.Value(),
+	}
+}
+
+type ExchangePayload struct {
+	Fixed             bool   `json:"fixed"`
+	CurrencyFrom      string `json:"currency_from"`
+	CurrencyTo        string `json:"currency_to"`
+	Amount            string `json:"amount"`
+	AddressTo         string `json:"address_to"`
+	ExtraIdTo         string `json:"extra_id_to"`
+	UserRefundAddress string `json:"user_refund_address"`
+	UserRefundExtraId string `json:"user_refund_extra_id"`
+}
+
+func NewExchangePayload(swap models.Swap, tickerFrom, tickerTo string) ExchangePayload {
+	return ExchangePayload{
+		CurrencyFrom:      tickerFrom,
+		CurrencyTo:        tickerTo,
+		Amount:            strconv.FormatFloat(swap.PayinAmount, 'f', -1, 64),
+		AddressTo:         swap.ToAddress,
+		ExtraIdTo:         swap.ToExtraId,
+		UserRefundAddress: swap.RefundAddress,
+		UserRefundExtraId: swap.RefundExtraId,
+	}
+}
+
+type Exchange struct {
+	Id          string         `json:"id"`
+	AddressFrom string         `json:"address_from"`
+	ExtraIdFrom string         `json:"extra_id_from"`
+	AmountFrom  parser.Float64 `json:"amount_from"`
+	AmountTo    parser.Float64 `json:"amount_to"`
+	Status      string         `json:"status"`
+	TxFrom      string         `json:"tx_from"`
+	TxTo        string         `json:"tx_to"`
+}
+
+// exchangeStatuses maps SimpleSwap exchange statuses to ours.
+var exchangeStatuses = map[string]string{
+	"waiting":    models.SwapStatusPending,
+	"confirming": models.SwapStatusConfirming,
+	"verifying":  models.SwapStatusConfirming,
+	"exchanging": models.SwapStatusExchanging,
+	"sending":    models.SwapStatusSending,
+	"finished":   models.SwapStatusCompleted,
+	"failed":     models.SwapStatusFailed,
+	"refunded":   models.SwapStatusRefunded,
+	"expired":    models.SwapStatusExpired,
+}
+
+func (e Exchange) ToExchangeSwapStatus() models.ExchangeSwapStatus {
+	return models.NewExchangeSwapStatus(e.Status, exchangeStatuses).
+		WithHashes(e.TxFrom, e.TxTo)
+}
+
+func (e Exchange) ToExchangeSwap() models.ExchangeSwap {
+	return models.ExchangeSwap{
+		ExchangeId:     e.Id,
+		DepositAddress: e.AddressFrom,
+		DepositExtraId: e.ExtraIdFrom,
+		PayinAmount:    e.AmountFrom.Value(),
+		PayoutAmount:   e.AmountTo.Value(),
+	}
+}
@@ -0,0 +1,179 @@
+package simpleswap
+
+import (
+	"context"
+	"cryptoswap/internal/lib/apierrors"
+	"cryptoswap/internal/lib/httpclient"
+	"cryptoswap/internal/lib/logger"
+	"cryptoswap/internal/services/interfaces"
+	"cryptoswap/internal/services/models"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/samber/lo"
+)
+
+const (
+	apiName = "SimpleSwap"
+)
+
+var (
+	_ interfaces.CurrencyFetcher   = &simpleSwapRepository{}
+	_ interfaces.SwapCreator       = &simpleSwapRepository{}
+	_ interfaces.SwapStatusFetcher = &simpleSwapRepository{}
+	_ interfaces.LimitFetcher      = &simpleSwapRepository{}
+)
+
+func NewSimpleSwapRepository(logger logger.Logger,
+	factory httpclient.Factory) *simpleSwapRepository {
+	return &simpleSwapRepository{
+		logger:   logger,
+		factory:  factory,
+		networks: newNetworkTranslator(),
+		tickers:  make(map[models.NetworkPair]string),
+	}
+}
+
+type simpleSwapRepository struct {
+	logger   logger.Logger
+	factory  httpclient.Factory
+	networks models.NetworkTranslator
+
+	// SimpleSwap names tokens after their network (usdterc20), so the tickers
+	// seen in /get_all_currencies are cached to translate our pairs back.
+	mu      sync.RWMutex
+	tickers map[models.NetworkPair]string
+}
+
+func (ss *simpleSwapRepository) GetExchangeName() string {
+	return apiName
+}
+
+func (ss *simpleSwapRepository) GetCurrencies(ctx context.Context) ([]models.Currency, *apierrors.ApiError) {
+	currencies, err := ss.fetchCurrencies(ctx)
+	if err != nil {
+		return []models.Currency{}, err
+	}
+
+	return lo.FilterMap(currencies, func(curr Currency, _ int) (models.Currency, bool) {
+		return curr.ToModel(ss.GetExchangeName(), ss.networks), !curr.IsFiat
+	}), nil
+}
+
+// GetQuote only answers direct floating quotes, SimpleSwap doesn't hand out
+// rate ids to lock a fixed estimation nor estimates the amount to send.
+func (ss *simpleSwapRepository) GetQuote(ctx context.Context, quoteRequest models.QuoteRequest,
+) (models.Quote, *apierrors.ApiError) {
+	if quoteRequest.Rate.IsFixed() || quoteRequest.AmountType.IsReverse() {
+		return models.Quote{}, nil
+	}
+
+	request := ss.factory.NewClient(ctx).
+		WithQueryParams("fixed", false).
+		WithQueryParams("currency_from", ss.getTicker(ctx, quoteRequest.From)).
+		WithQueryParams("currency_to", ss.getTicker(ctx, quoteRequest.To)).
+		WithQueryParams("amount", strconv.FormatFloat(quoteRequest.Amount, 'f', -1, 64)).
+		Get
+
+	estimation, err := httpclient.HandleRequest[Estimation](request, "/get_estimated", http.StatusOK)
+	if err != nil {
+		return models.Quote{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return estimation.ToQuote(ss.GetExchangeName(), quoteRequest), nil
+}
+
+func (ss *simpleSwapRepository) GetLimits(ctx context.Context, from, to models.NetworkPair,
+	rate models.RateMode) (models.Limits, *apierrors.ApiError) {
+	request := ss.factory.NewClient(ctx).
+		WithQueryParams("fixed", rate.IsFixed()).
+		WithQueryParams("currency_from", ss.getTicker(ctx, from)).
+		WithQueryParams("currency_to", ss.getTicker(ctx, to)).
+		Get
+
+	ranges, err := httpclient.HandleRequest[Ranges](request, "/get_ranges", http.StatusOK)
+	if err != nil {
+		return models.Limits{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return ranges.ToLimits(), nil
+}
+
+// CreateSwap opens a floating swap, the only kind GetQuote hands out.
+func (ss *simpleSwapRepository) CreateSwap(ctx context.Context, swap models.Swap,
+) (models.ExchangeSwap, *apierrors.ApiError) {
+	payload := NewExchangePayload(swap, ss.getTicker(ctx, swap.From), ss.getTicker(ctx, swap.To))
+	request := ss.factory.NewClient(ctx).
+		WithBody(payload).
+		Post
+
+	exchange, err := httpclient.HandleRequest[Exchange](request, "/create_exchange", http.StatusOK)
+	if err != nil {
+		return models.ExchangeSwap{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return exchange.ToExchangeSwap(), nil
+}
+
+func (ss *simpleSwapRepository) GetSwapStatus(ctx context.Context, exchangeId string,
+) (models.ExchangeSwapStatus, *apierrors.ApiError) {
+	request := ss.factory.NewClient(ctx).
+		WithQueryParams("id", exchangeId).
+		Get
+
+	exchange, err := httpclient.HandleRequest[Exchange](request, "/get_exchange", http.StatusOK)
+	if err != nil {
+		return models.ExchangeSwapStatus{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return exchange.ToExchangeSwapStatus(), nil
+}
+
+func (ss *simpleSwapRepository) fetchCurrencies(ctx context.Context) ([]Currency, *apierrors.ApiError) {
+	request := ss.factory.NewClient(ctx).Get
+
+	currencies, err := httpclient.HandleRequest[[]Currency](request, "/get_all_currencies", http.StatusOK)
+	if err != nil {
+		return nil, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	ss.storeTickers(currencies)
+	return currencies, nil
+}
+
+func (ss *simpleSwapRepository) storeTickers(currencies []Currency) {
+	tickers := make(map[models.NetworkPair]string, len(currencies))
+	for _, currency := range currencies {
+		tickers[currency.ToNetworkPair(ss.networks)] = currency.Symbol
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.tickers = tickers
+}
+
+// getTicker translates a canonical pair into the SimpleSwap ticker, loading
+// the listing first when it wasn't fetched yet. Unknown tokens fall back to
+// the symbol followed by the network code.
+func (ss *simpleSwapRepository) getTicker(ctx context.Context, pair models.NetworkPair) string {
+	ss.mu.RLock()
+	loaded := len(ss.tickers) > 0
+	ss.mu.RUnlock()
+	if !loaded {
+		if _, err := ss.fetchCurrencies(ctx); err != nil {
+			ss.logger.Warningf(ctx, "Could not load tickers, using fallback ones: %v", err)
+		}
+	}
+
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	if ticker, ok := ss.tickers[pair]; ok {
+		return ticker
+	}
+
+	if pair.Network == models.NativeNetwork(pair.Symbol) {
+		return pair.Symbol
+	}
+	return pair.Symbol + ss.networks.ToProvider(pair)
+}
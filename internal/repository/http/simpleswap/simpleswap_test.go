@@ -0,0 +1,198 @@
+package simpleswap
+
+import (
+	"context"
+	"cryptoswap/internal/lib/httpclient/httpclienttest"
+	"cryptoswap/internal/services/models"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func newTestRepository(t *testing.T, routes ...httpclienttest.Route) (*simpleSwapRepository, *httpclienttest.Server) {
+	server := httpclienttest.NewServer(t, routes...)
+	return NewSimpleSwapRepository(httpclienttest.NewLogger("simpleswap"), server.Factory()), server
+}
+
+var currenciesRoute = httpclienttest.NewRoute(http.MethodGet, "/get_all_currencies", http.StatusOK,
+	"currencies.json")
+
+func TestGetCurrencies(t *testing.T) {
+	repository, _ := newTestRepository(t, currenciesRoute)
+
+	currencies, err := repository.GetCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []models.NetworkPair{
+		models.NewNetworkPair("btc", "btc"),
+		models.NewNetworkPair("usdt", "eth"),
+		models.NewNetworkPair("usdt", "bsc"),
+	}
+	if len(currencies) != len(want) {
+		t.Fatalf("expected %d currencies without fiat, got %d", len(want), len(currencies))
+	}
+	for i, pair := range want {
+		networks := currencies[i].GetNetworks()
+		if len(networks) != 1 || networks[0] != pair {
+			t.Errorf("currency %d: expected network %s, got %v", i, pair, networks)
+		}
+	}
+}
+
+func TestGetQuote_Tickers(t *testing.T) {
+	repository, server := newTestRepository(t, currenciesRoute,
+		httpclienttest.NewRoute(http.MethodGet, "/get_estimated", http.StatusOK, "estimated.json"))
+
+	quote, err := repository.GetQuote(context.Background(), models.NewQuoteRequest(
+		models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("usdt", "bsc"), 0.1, models.RateFloating))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if quote.Amount != 6498.2281 {
+		t.Errorf("unexpected quote: %+v", quote)
+	}
+
+	// The listing is loaded first to learn the tickers
+	requests := server.Requests()
+	if len(requests) != 2 || requests[0].Path != "/get_all_currencies" {
+		t.Fatalf("expected the listing before the estimation, got %+v", requests)
+	}
+	query := requests[1].Query
+	if query.Get("currency_from") != "btc" || query.Get("currency_to") != "usdtbep20" {
+		t.Errorf("expected the SimpleSwap tickers, got %v", query)
+	}
+}
+
+func TestGetLimits(t *testing.T) {
+	repository, server := newTestRepository(t, currenciesRoute,
+		httpclienttest.NewRoute(http.MethodGet, "/get_ranges", http.StatusOK, "ranges.json"))
+
+	limits, err := repository.GetLimits(context.Background(), models.NewNetworkPair("usdt", "eth"),
+		models.NewNetworkPair("btc", "btc"), models.RateFloating)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if limits.Min != 0.0006 || limits.Max != 0 {
+		t.Errorf("unexpected limits: %+v", limits)
+	}
+	if got := server.Requests()[1].Query.Get("currency_from"); got != "usdterc20" {
+		t.Errorf("expected currency_from=usdterc20, got %q", got)
+	}
+}
+
+func TestCreateSwap(t *testing.T) {
+	repository, server := newTestRepository(t, currenciesRoute,
+		httpclienttest.NewRoute(http.MethodPost, "/create_exchange", http.StatusOK, "exchange.json"))
+
+	swap := models.Swap{
+		From:          models.NewNetworkPair("btc", "btc"),
+		To:            models.NewNetworkPair("usdt", "bsc"),
+		PayinAmount:   0.1,
+		ToAddress:     "0x9f8c0b1d2e3a4b5c6d7e8f90a1b2c3d4e5f60718",
+		RefundAddress: "bc1qrefund000000000000000000000000000000",
+	}
+	exchange, err := repository.CreateSwap(context.Background(), swap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if exchange.ExchangeId != "ss7xkq2mv9bz" || exchange.DepositAddress != "bc1qsimpleswapdeposit0000000000000000000" ||
+		exchange.PayinAmount != 0.1 || exchange.PayoutAmount != 6498.2281 {
+		t.Errorf("unexpected exchange: %+v", exchange)
+	}
+
+	var payload ExchangePayload
+	if err := json.Unmarshal(server.Requests()[1].Body, &payload); err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	if payload.Fixed || payload.CurrencyFrom != "btc" || payload.CurrencyTo != "usdtbep20" ||
+		payload.Amount != "0.1" || payload.AddressTo != swap.ToAddress {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestCreateSwap_ErrorStatus(t *testing.T) {
+	repository, _ := newTestRepository(t, currenciesRoute,
+		httpclienttest.NewRoute(http.MethodPost, "/create_exchange", http.StatusBadRequest, "out_of_range.json"))
+
+	swap := models.Swap{From: models.NewNetworkPair("btc", "btc"), To: models.NewNetworkPair("usdt", "eth")}
+	if _, err := repository.CreateSwap(context.Background(), swap); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestGetSwapStatus(t *testing.T) {
+	repository, _ := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/get_exchange", http.StatusOK, "exchange_sending.json").
+			WithQuery("id", "ss7xkq2mv9bz"))
+
+	status, err := repository.GetSwapStatus(context.Background(), "ss7xkq2mv9bz")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Status != models.SwapStatusSending || status.PayinHash == "" || status.PayoutHash != "" {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestCurrency_GetSymbol(t *testing.T) {
+	for _, tt := range []struct {
+		symbol, network, want string
+	}{
+		{symbol: "usdterc20", network: "erc20", want: "usdt"},
+		{symbol: "USDTTRC20", network: "TRC20", want: "usdt"},
+		{symbol: "weth", network: "eth", want: "weth"},
+		{symbol: "steth", network: "eth", want: "steth"},
+		{symbol: "msol", network: "sol", want: "msol"},
+		{symbol: "jitosol", network: "sol", want: "jitosol"},
+		{symbol: "btc", network: "btc", want: "btc"},
+	} {
+		if got := (Currency{Symbol: tt.symbol, Network: tt.network}).GetSymbol(); got != tt.want {
+			t.Errorf("%s on %s: expected %s, got %s", tt.symbol, tt.network, tt.want, got)
+		}
+	}
+}
+
+func TestGetTicker_Fallback(t *testing.T) {
+	repository, _ := newTestRepository(t)
+
+	tests := []struct {
+		pair models.NetworkPair
+		want string
+	}{
+		{pair: models.NewNetworkPair("ltc", "ltc"), want: "ltc"},
+		{pair: models.NewNetworkPair("usdc", "sol"), want: "usdcsol"},
+	}
+	for _, tt := range tests {
+		if got := repository.getTicker(context.Background(), tt.pair); got != tt.want {
+			t.Errorf("%s: expected ticker %s, got %s", tt.pair, tt.want, got)
+		}
+	}
+}
+
+func TestNetworkRoundTrip(t *testing.T) {
+	repository, _ := newTestRepository(t, currenciesRoute)
+
+	currencies, err := repository.GetCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Every listed network goes back to SimpleSwap as the ticker it was listed with
+	listed := map[models.NetworkPair]string{
+		models.NewNetworkPair("btc", "btc"):  "btc",
+		models.NewNetworkPair("usdt", "eth"): "usdterc20",
+		models.NewNetworkPair("usdt", "bsc"): "usdtbep20",
+	}
+	for _, currency := range currencies {
+		for _, pair := range currency.GetNetworks() {
+			if got := repository.getTicker(context.Background(), pair); got != listed[pair] {
+				t.Errorf("%s: expected ticker %q, got %q", pair, listed[pair], got)
+			}
+		}
+	}
+}
@@ -6,6 +6,12 @@ const (
 	stealthEx = "StealthEX"
 )
 
+// NewNetworkTranslator translates StealthEX network codes, which name any coin
+// on its own chain "mainnet".
+func NewNetworkTranslator() models.NetworkTranslator {
+	return models.NewNetworkTranslator(nil).WithNativeCode("mainnet")
+}
+
 type CurrencyResponse struct {
 	Symbol            string   `json:"symbol"`
 	Name              string   `json:"name"`
@@ -22,13 +28,20 @@ type CurrencyResponse struct {
 	ExtraId           string   `json:"extra_id"`
 }
 
-func (c *CurrencyResponse) ToNetworkPair() models.NetworkPair {
+func (c *CurrencyResponse) ToNetworkPair(networks models.NetworkTranslator) models.NetworkPair {
 	return models.NetworkPair{
 		Symbol:  c.Symbol,
-		Network: c.Network,
+		Network: networks.ToCanonical(c.Symbol, c.Network),
 	}
 }
 
-func (c *CurrencyResponse) ToCurrency() models.Currency {
-	return models.NewCurrency(stealthEx, c.Network, c.Symbol, c.Name, c.ValidationAddress, c.Image, true)
+func (c *CurrencyResponse) ToCurrency(networks models.NetworkTranslator) models.Currency {
+	network := networks.ToCanonical(c.Symbol, c.Network)
+	return models.NewCurrency(stealthEx, network, c.Symbol, c.Name, c.ValidationAddress, c.Image, true).
+		WithNetworkInfo(models.NetworkInfo{
+			HasExtraId:        c.HasExtraId,
+			ExtraIdValidation: c.ValidationExtra,
+			AddressExplorer:   c.AddressExplorer,
+			TxExplorer:        c.TxExplorer,
+		}.WithWarnings(stealthEx, models.Warnings{From: c.WarningsFrom, To: c.WarningsTo}))
 }
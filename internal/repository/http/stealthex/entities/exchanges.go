@@ -0,0 +1,73 @@
+package entities
+
+import "cryptoswap/internal/services/models"
+
+func NewExchangePayload(swap models.Swap, networks models.NetworkTranslator) ExchangePayload {
+	return ExchangePayload{
+		Route:         NewRoute(swap.From, swap.To, networks),
+		Amount:        swap.PayinAmount,
+		Estimation:    "direct",
+		Rate:          string(swap.GetRateMode()),
+		RateId:        swap.RateId,
+		Address:       swap.ToAddress,
+		ExtraId:       swap.ToExtraId,
+		RefundAddress: swap.RefundAddress,
+		RefundExtraId: swap.RefundExtraId,
+	}
+}
+
+type ExchangePayload struct {
+	Route         Route   `json:"route"`
+	Amount        float64 `json:"amount"`
+	Estimation    string  `json:"estimation"`
+	Rate          string  `json:"rate"`
+	RateId        string  `json:"rate_id,omitempty"`
+	Address       string  `json:"address"`
+	ExtraId       string  `json:"extra_id,omitempty"`
+	RefundAddress string  `json:"refund_address,omitempty"`
+	RefundExtraId string  `json:"refund_extra_id,omitempty"`
+}
+
+type Transfer struct {
+	Symbol  string  `json:"symbol"`
+	Network string  `json:"network"`
+	Amount  float64 `json:"amount"`
+	Address string  `json:"address"`
+	ExtraId string  `json:"extra_id"`
+	TxHash  string  `json:"tx_hash"`
+}
+
+type ExchangeResponse struct {
+	Id         string   `json:"id"`
+	Status     string   `json:"status"`
+	Deposit    Transfer `json:"deposit"`
+	Withdrawal Transfer `json:"withdrawal"`
+}
+
+func (e *ExchangeResponse) ToExchangeSwap() models.ExchangeSwap {
+	return models.ExchangeSwap{
+		ExchangeId:     e.Id,
+		DepositAddress: e.Deposit.Address,
+		DepositExtraId: e.Deposit.ExtraId,
+		PayinAmount:    e.Deposit.Amount,
+		PayoutAmount:   e.Withdrawal.Amount,
+	}
+}
+
+// exchangeStatuses maps StealthEX exchange statuses to ours.
+var exchangeStatuses = map[string]string{
+	"waiting":    models.SwapStatusPending,
+	"confirming": models.SwapStatusConfirming,
+	"verifying":  models.SwapStatusConfirming,
+	"exchanging": models.SwapStatusExchanging,
+	"sending":    models.SwapStatusSending,
+	"finished":   models.SwapStatusCompleted,
+	"failed":     models.SwapStatusFailed,
+	"refunded":   models.SwapStatusRefunded,
+	"expired":    models.SwapStatusExpired,
+}
+
+func (e *ExchangeResponse) ToExchangeSwapStatus() models.ExchangeSwapStatus {
+	return models.NewExchangeSwapStatus(e.Status, exchangeStatuses).
+		WithHashes(e.Deposit.TxHash, e.Withdrawal.TxHash)
+}
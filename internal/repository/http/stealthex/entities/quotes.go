@@ -1,16 +1,34 @@
 package entities
 
-import "cryptoswap/internal/services/models"
+import (
+	"cryptoswap/internal/lib/parser"
+	"cryptoswap/internal/services/models"
+	"time"
 
-func NewQuotePayload(from, to models.NetworkPair, amount float64) QuotePayload {
+	"github.com/samber/lo"
+)
+
+const (
+	estimationDirect   = "direct"
+	estimationReversed = "reversed"
+)
+
+// NewQuotePayload asks for the amount received, or for the amount to send on
+// reversed estimations.
+func NewQuotePayload(request models.QuoteRequest, networks models.NetworkTranslator) QuotePayload {
 	return QuotePayload{
-		Route: Route{
-			From: from,
-			To:   to,
-		},
-		Estimation: "direct",
-		Rate:       "floating",
-		Amount:     amount,
+		Route:      NewRoute(request.From, request.To, networks),
+		Estimation: lo.Ternary(request.AmountType.IsReverse(), estimationReversed, estimationDirect),
+		Rate:       string(request.Rate),
+		Amount:     request.Amount,
+	}
+}
+
+// NewRoute translates the pairs to the networks as StealthEX names them.
+func NewRoute(from, to models.NetworkPair, networks models.NetworkTranslator) Route {
+	return Route{
+		From: networks.ToProviderPair(from),
+		To:   networks.ToProviderPair(to),
 	}
 }
 
@@ -27,14 +45,47 @@ type QuotePayload struct {
 }
 
 type QuoteResponse struct {
-	EstimatedAmount float64 `json:"estimated_amount"`
+	EstimatedAmount float64    `json:"estimated_amount"`
+	Rate            *QuoteRate `json:"rate"`
+}
+
+// QuoteRate is only sent for fixed rate estimations.
+type QuoteRate struct {
+	Id         string    `json:"id"`
+	ValidUntil time.Time `json:"valid_until"`
+}
+
+func (q *QuoteResponse) ToQuote(request models.QuoteRequest) models.Quote {
+	quote := request.NewQuote(stealthEx, q.EstimatedAmount)
+	if q.Rate == nil {
+		return quote
+	}
+	return quote.WithRate(q.Rate.Id, q.Rate.ValidUntil)
+}
+
+func NewRangePayload(from, to models.NetworkPair, rate models.RateMode,
+	networks models.NetworkTranslator) RangePayload {
+	return RangePayload{
+		Route:      NewRoute(from, to, networks),
+		Estimation: estimationDirect,
+		Rate:       string(rate),
+	}
+}
+
+type RangePayload struct {
+	Route      Route  `json:"route"`
+	Estimation string `json:"estimation"`
+	Rate       string `json:"rate"`
+}
+
+type RangeResponse struct {
+	MinAmount parser.Float64 `json:"min_amount"`
+	MaxAmount parser.Float64 `json:"max_amount"`
 }
 
-func (q *QuoteResponse) ToQuote(from, to models.NetworkPair) models.Quote {
-	return models.Quote{
-		From:     from,
-		To:       to,
-		Amount:   q.EstimatedAmount,
-		Exchange: stealthEx,
+func (r *RangeResponse) ToLimits() models.Limits {
+	return models.Limits{
+		Min: r.MinAmount.Value(),
+		Max: r.MaxAmount.Value(),
 	}
 }
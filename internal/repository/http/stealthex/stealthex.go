@@ -9,6 +9,7 @@ import (
 	"cryptoswap/internal/services/interfaces"
 	"cryptoswap/internal/services/models"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/samber/lo"
@@ -19,18 +20,25 @@ const (
 	limit     = 250
 )
 
-var _ interfaces.CurrencyFetcher = &stealthexClientImpl{}
+var (
+	_ interfaces.CurrencyFetcher   = &stealthexClientImpl{}
+	_ interfaces.SwapCreator       = &stealthexClientImpl{}
+	_ interfaces.SwapStatusFetcher = &stealthexClientImpl{}
+	_ interfaces.LimitFetcher      = &stealthexClientImpl{}
+)
 
 type stealthexClientImpl struct {
-	factory httpclient.Factory
-	logger  logger.Logger
+	factory  httpclient.Factory
+	logger   logger.Logger
+	networks models.NetworkTranslator
 }
 
 func NewStealthExRepository(logger logger.Logger,
 	factory httpclient.Factory) *stealthexClientImpl {
 	return &stealthexClientImpl{
-		logger:  logger,
-		factory: factory,
+		logger:   logger,
+		factory:  factory,
+		networks: entities.NewNetworkTranslator(),
 	}
 }
 
@@ -50,13 +58,12 @@ func (s *stealthexClientImpl) GetCurrencies(ctx context.Context,
 			WithQueryParams("offset", offset).Get
 		apiCurrencies, err := httpclient.HandleRequest[[]entities.CurrencyResponse](
 			request, "/currencies", http.StatusOK)
-
-		if len(apiCurrencies) == 0 {
-			break
+		if err != nil {
+			s.logger.Errorf(ctx, "Error fetching currencies at offset %d: %v", offset, err)
+			return []models.Currency{}, apierrors.NewApiError(apierrors.InternalServer, err)
 		}
 
-		if err != nil {
-			s.logger.Error(ctx, "error fetching currencies", "error", err)
+		if len(apiCurrencies) == 0 {
 			break
 		}
 
@@ -66,23 +73,21 @@ func (s *stealthexClientImpl) GetCurrencies(ctx context.Context,
 	}
 
 	return lo.Map(currs, func(curr entities.CurrencyResponse, _ int) models.Currency {
-		return curr.ToCurrency()
+		return curr.ToCurrency(s.networks)
 	}), nil
 }
 
 func getOffset(count int) int {
-	if count == 0 {
-		return 0
-	}
-	return count*limit + 1
+	return count * limit
 }
 
-func (s *stealthexClientImpl) GetQuote(ctx context.Context, from, to models.NetworkPair,
-	amount float64) (models.Quote, *apierrors.ApiError) {
+func (s *stealthexClientImpl) GetQuote(ctx context.Context, quoteRequest models.QuoteRequest,
+) (models.Quote, *apierrors.ApiError) {
 
-	payload := entities.NewQuotePayload(from, to, amount)
+	payload := entities.NewQuotePayload(quoteRequest, s.networks)
 	request := s.factory.NewClient(ctx).
 		WithBody(payload).
+		WithIdempotent().
 		Post
 
 	quote, err := httpclient.HandleRequest[entities.QuoteResponse](
@@ -91,5 +96,52 @@ func (s *stealthexClientImpl) GetQuote(ctx context.Context, from, to models.Netw
 		return models.Quote{}, apierrors.NewApiError(apierrors.InternalServer, err)
 	}
 
-	return quote.ToQuote(from, to), nil
+	return quote.ToQuote(quoteRequest), nil
+}
+
+func (s *stealthexClientImpl) GetLimits(ctx context.Context, from, to models.NetworkPair,
+	rate models.RateMode) (models.Limits, *apierrors.ApiError) {
+
+	request := s.factory.NewClient(ctx).
+		WithBody(entities.NewRangePayload(from, to, rate, s.networks)).
+		WithIdempotent().
+		Post
+
+	rng, err := httpclient.HandleRequest[entities.RangeResponse](
+		request, "/rates/range", http.StatusOK)
+	if err != nil {
+		return models.Limits{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return rng.ToLimits(), nil
+}
+
+func (s *stealthexClientImpl) CreateSwap(ctx context.Context, swap models.Swap,
+) (models.ExchangeSwap, *apierrors.ApiError) {
+
+	payload := entities.NewExchangePayload(swap, s.networks)
+	request := s.factory.NewClient(ctx).
+		WithBody(payload).
+		Post
+
+	exchange, err := httpclient.HandleRequest[entities.ExchangeResponse](
+		request, "/exchanges", http.StatusCreated)
+	if err != nil {
+		return models.ExchangeSwap{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return exchange.ToExchangeSwap(), nil
+}
+
+func (s *stealthexClientImpl) GetSwapStatus(ctx context.Context, exchangeId string,
+) (models.ExchangeSwapStatus, *apierrors.ApiError) {
+
+	request := s.factory.NewClient(ctx).Get
+	exchange, err := httpclient.HandleRequest[entities.ExchangeResponse](
+		request, "/exchanges/"+url.PathEscape(exchangeId), http.StatusOK)
+	if err != nil {
+		return models.ExchangeSwapStatus{}, apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	return exchange.ToExchangeSwapStatus(), nil
 }
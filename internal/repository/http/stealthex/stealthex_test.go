@@ -2,36 +2,233 @@ package stealthex
 
 import (
 	"context"
-	"cryptoswap/internal/lib/httpclient"
-	"cryptoswap/internal/lib/logger"
+	"cryptoswap/internal/lib/apierrors"
+	"cryptoswap/internal/lib/httpclient/httpclienttest"
+	"cryptoswap/internal/repository/http/stealthex/entities"
 	"cryptoswap/internal/services/models"
+	"encoding/json"
+	"net/http"
+	"strconv"
 	"testing"
 	"time"
 )
 
-func Test_Stealth(t *testing.T) {
-	fact := logger.NewLoggerFactory("test", "info")
+func newTestRepository(t *testing.T, routes ...httpclienttest.Route) (*stealthexClientImpl, *httpclienttest.Server) {
+	server := httpclienttest.NewServer(t, routes...)
+	return NewStealthExRepository(httpclienttest.NewLogger("stealthex"), server.Factory()), server
+}
+
+func currenciesPage(offset int, fixture string) httpclienttest.Route {
+	return httpclienttest.NewRoute(http.MethodGet, "/currencies", http.StatusOK, fixture).
+		WithQuery("limit", strconv.Itoa(limit)).
+		WithQuery("offset", strconv.Itoa(offset))
+}
+
+func TestGetOffset(t *testing.T) {
+	for count, want := range []int{0, limit, 2 * limit} {
+		if got := getOffset(count); got != want {
+			t.Errorf("getOffset(%d): expected %d, got %d", count, want, got)
+		}
+	}
+}
 
-	httpFact := httpclient.NewFactory(httpclient.HttpConfig{
-		BaseURL:    "https://api.stealthex.io/v4",
-		Timeout:    10 * time.Second,
-		AuthScheme: "Bearer",
-		ApiKey:     "86db32f5-f673-4d3e-a0b2-9615701d47f5",
-	}, fact.NewLogger("http_client"))
-	client := NewStealthExRepository(fact.NewLogger("stealthex"), httpFact)
+func TestGetCurrencies_Pagination(t *testing.T) {
+	repository, server := newTestRepository(t,
+		currenciesPage(0, "currencies_page_0.json"),
+		currenciesPage(limit, "currencies_page_1.json"),
+		currenciesPage(2*limit, "empty.json"))
 
-	ctx := context.Background()
-	from := models.NetworkPair{
-		Symbol:  "btc",
-		Network: "mainnet",
+	currencies, err := repository.GetCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-	to := models.NetworkPair{
-		Symbol:  "eth",
-		Network: "mainnet",
+
+	requests := server.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 page requests, got %d", len(requests))
 	}
-	quote, err := client.GetQuote(ctx, from, to, 0.1)
+	for i, request := range requests {
+		if got := request.Query.Get("offset"); got != strconv.Itoa(i*limit) {
+			t.Errorf("page %d: expected offset %d, got %s", i, i*limit, got)
+		}
+	}
+
+	if len(currencies) != 3 {
+		t.Fatalf("expected 3 currencies, got %d", len(currencies))
+	}
+
+	// Coins on their own chain come as "mainnet" and are stored on our network code
+	wantPairs := []models.NetworkPair{
+		models.NewNetworkPair("btc", "btc"),
+		models.NewNetworkPair("usdt", "bsc"),
+		models.NewNetworkPair("xrp", "xrp"),
+	}
+	for i, pair := range wantPairs {
+		if networks := currencies[i].GetNetworks(); len(networks) != 1 || networks[0] != pair {
+			t.Errorf("currency %d: expected network %s, got %v", i, pair, networks)
+		}
+	}
+}
+
+func TestGetCurrencies_NetworkInfo(t *testing.T) {
+	repository, _ := newTestRepository(t,
+		currenciesPage(0, "currencies_page_1.json"),
+		currenciesPage(limit, "empty.json"))
+
+	currencies, err := repository.GetCurrencies(context.Background())
 	if err != nil {
-		t.Fatalf("error getting quote: %v", err)
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	xrp := models.NewNetworkPair("xrp", "xrp")
+	info := currencies[0].GetNetworkInfo(xrp)
+	if !info.HasExtraId || info.ExtraIdValidation != "^[0-9]{1,10}$" {
+		t.Errorf("expected extra id info, got %+v", info)
+	}
+	if got := info.GetTxLink("ABC123"); got != "https://xrpscan.com/tx/ABC123" {
+		t.Errorf("unexpected tx link %q", got)
+	}
+	warnings := info.GetWarnings(stealthEx)
+	if len(warnings.From) != 0 || len(warnings.To) != 1 {
+		t.Errorf("unexpected warnings %+v", warnings)
+	}
+}
+
+func TestGetCurrencies_ErrorStatus(t *testing.T) {
+	repository, _ := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodGet, "/currencies", http.StatusUnauthorized, "unauthorized.json"))
+
+	_, err := repository.GetCurrencies(context.Background())
+	if err == nil || err.Code != apierrors.InternalServer.Code {
+		t.Fatalf("expected internal server error, got %v", err)
+	}
+}
+
+func TestGetQuote(t *testing.T) {
+	from := models.NewNetworkPair("btc", "btc")
+	to := models.NewNetworkPair("usdt", "bsc")
+
+	repository, server := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodPost, "/rates/estimated-amount", http.StatusOK,
+			"estimated_amount.json"))
+
+	quote, err := repository.GetQuote(context.Background(),
+		models.NewQuoteRequest(from, to, 0.1, models.RateFloating))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if quote.Amount != 2.71828 || quote.Exchange != stealthEx || quote.From != from || quote.To != to {
+		t.Errorf("unexpected quote: %+v", quote)
+	}
+
+	payload := entities.QuotePayload{}
+	if err := json.Unmarshal(server.Requests()[0].Body, &payload); err != nil {
+		t.Fatalf("unexpected payload: %v", err)
+	}
+
+	// Our network codes are sent as StealthEX names them
+	wantRoute := entities.Route{
+		From: models.NetworkPair{Symbol: "btc", Network: "mainnet"},
+		To:   models.NetworkPair{Symbol: "usdt", Network: "bsc"},
+	}
+	if payload.Route != wantRoute || payload.Amount != 0.1 || payload.Rate != "floating" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestGetQuote_FixedRate(t *testing.T) {
+	repository, _ := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodPost, "/rates/estimated-amount", http.StatusOK,
+			"estimated_amount_fixed.json"))
+
+	quote, err := repository.GetQuote(context.Background(), models.NewQuoteRequest(
+		models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("eth", "eth"), 0.1, models.RateFixed))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	validUntil := time.Date(2025, 9, 1, 10, 15, 30, 0, time.UTC)
+	if quote.RateId != "c2f6e7a4-3b5d-4a4e-9d0a-3f6b2b9c1e11" || !quote.ValidUntil.Equal(validUntil) {
+		t.Errorf("unexpected fixed quote: %+v", quote)
+	}
+}
+
+func TestGetQuote_ErrorStatus(t *testing.T) {
+	repository, _ := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodPost, "/rates/estimated-amount", http.StatusUnauthorized,
+			"unauthorized.json"))
+
+	_, err := repository.GetQuote(context.Background(), models.NewQuoteRequest(
+		models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("eth", "eth"), 0.1, models.RateFloating))
+	if err == nil || err.Code != apierrors.InternalServer.Code {
+		t.Fatalf("expected internal server error, got %v", err)
+	}
+}
+
+func TestGetQuote_Reversed(t *testing.T) {
+	repository, server := newTestRepository(t,
+		httpclienttest.NewRoute(http.MethodPost, "/rates/estimated-amount", http.StatusOK,
+			"estimated_amount.json"))
+
+	request := models.NewQuoteRequest(models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("eth", "eth"),
+		5, models.RateFloating).WithAmountType(models.AmountTo)
+	quote, err := repository.GetQuote(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The estimation is the amount to send to receive the requested one
+	if quote.FromAmount != 2.71828 || quote.Amount != 5 {
+		t.Errorf("unexpected reverse quote: %+v", quote)
+	}
+
+	payload := entities.QuotePayload{}
+	if err := json.Unmarshal(server.Requests()[0].Body, &payload); err != nil {
+		t.Fatalf("unexpected payload: %v", err)
+	}
+	if payload.Estimation != "reversed" || payload.Amount != 5 {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestNetworkRoundTrip(t *testing.T) {
+	repository, server := newTestRepository(t,
+		currenciesPage(0, "currencies_aliases.json"),
+		currenciesPage(limit, "empty.json"),
+		httpclienttest.NewRoute(http.MethodPost, "/rates/estimated-amount", http.StatusOK,
+			"estimated_amount.json"))
+
+	currencies, err := repository.GetCurrencies(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Every listed network goes back to StealthEX as it was listed
+	listed := map[models.NetworkPair]string{
+		models.NewNetworkPair("usdc", "bsc"):   "BEP20",
+		models.NewNetworkPair("usdt", "trx"):   "TRC20",
+		models.NewNetworkPair("avax", "avaxc"): "mainnet",
+	}
+	for _, currency := range currencies {
+		for _, pair := range currency.GetNetworks() {
+			if got := repository.networks.ToProvider(pair); got != listed[pair] {
+				t.Errorf("%s: expected network %q, got %q", pair, listed[pair], got)
+			}
+		}
+	}
+
+	_, err = repository.GetQuote(context.Background(), models.NewQuoteRequest(
+		models.NewNetworkPair("usdc", "bsc"), models.NewNetworkPair("usdt", "trx"), 100, models.RateFloating))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payload := entities.QuotePayload{}
+	if err := json.Unmarshal(server.Requests()[2].Body, &payload); err != nil {
+		t.Fatalf("unexpected payload: %v", err)
+	}
+	if payload.Route.From.Network != "BEP20" || payload.Route.To.Network != "TRC20" {
+		t.Errorf("expected the listed networks, got %+v", payload.Route)
 	}
-	t.Logf("quote: %+v", quote)
 }
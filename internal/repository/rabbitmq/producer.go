@@ -8,6 +8,7 @@ import (
 	"cryptoswap/internal/lib/messaging"
 	"cryptoswap/internal/services/interfaces"
 	"cryptoswap/internal/services/models"
+	"time"
 )
 
 func NewExchangeNotifier(logger logger.Logger, conn messaging.Publisher) interfaces.SwapNotifier {
@@ -23,12 +24,25 @@ type exchangeNotifier struct {
 }
 
 func (e *exchangeNotifier) NotifySwap(ctx context.Context, swap models.Swap) *apierrors.ApiError {
-	err := e.conn.Publish(ctx, messaging.NewMessageBuilder().
+	return e.publish(ctx, messaging.NewMessageBuilder().
 		WithRoutingKey(constants.SwapRoutingKey).
 		WithRequestId(constants.GetRequestId(ctx)).
 		WithJSONBody(swap).
 		Build())
-	if err != nil {
+}
+
+func (e *exchangeNotifier) NotifySwapLater(ctx context.Context, swap models.Swap,
+	delay time.Duration) *apierrors.ApiError {
+	return e.publish(ctx, messaging.NewMessageBuilder().
+		WithRoutingKey(constants.DelayedSwapRoutingKey).
+		WithRequestId(constants.GetRequestId(ctx)).
+		WithJSONBody(swap).
+		WithDelay(delay).
+		Build())
+}
+
+func (e *exchangeNotifier) publish(ctx context.Context, msg messaging.Message) *apierrors.ApiError {
+	if err := e.conn.Publish(ctx, msg); err != nil {
 		return apierrors.NewApiError(apierrors.InternalServer, err)
 	}
 	return nil
@@ -2,23 +2,44 @@ package currencies
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/samber/lo"
 
 	"cryptoswap/internal/lib/apierrors"
+	"cryptoswap/internal/lib/breaker"
+	"cryptoswap/internal/lib/cache"
 	"cryptoswap/internal/lib/logger"
+	"cryptoswap/internal/lib/singleflight"
 	"cryptoswap/internal/services/interfaces"
 	"cryptoswap/internal/services/models"
 )
 
+// statusCheckInterval is how long a swap waits before its status is
+// fetched again from the exchange.
+const statusCheckInterval = 30 * time.Second
+
+// swapTrackingTimeout is how long after its creation a swap status is
+// followed, the exchanges expire the swaps not paid well before.
+const swapTrackingTimeout = 72 * time.Hour
+
 type CurrencyService interface {
 	GetCurrencies(ctx context.Context, filters models.Filters) ([]models.Currency, *apierrors.ApiError)
-	GetQuotes(ctx context.Context, from, to models.NetworkPair, amount float64) ([]models.Quote, *apierrors.ApiError)
+	GetCurrency(ctx context.Context, symbol string) (models.Currency, *apierrors.ApiError)
+	GetPriceHistory(ctx context.Context, symbol string, interval models.CandleInterval,
+		limit int) (models.PriceHistory, *apierrors.ApiError)
+	GetQuotes(ctx context.Context, request models.QuoteRequest,
+		rank models.RankStrategy) (models.QuoteResult, *apierrors.ApiError)
+	GetSplitQuote(ctx context.Context, request models.QuoteRequest, parts int) (models.SplitQuote, *apierrors.ApiError)
+	GetQuoteAnalytics(ctx context.Context, from, to models.NetworkPair,
+		window time.Duration) (models.PairAnalytics, *apierrors.ApiError)
 	GetSwap(ctx context.Context, id string) (models.Swap, *apierrors.ApiError)
 	InsertSwap(ctx context.Context, swap models.Swap) (models.Swap, *apierrors.ApiError)
+	InsertSplitSwap(ctx context.Context, swap models.Swap) (models.Swap, *apierrors.ApiError)
 	ProcessSwap(ctx context.Context, swap models.Swap) *apierrors.ApiError
 }
 
@@ -29,6 +50,7 @@ func NewCurrencyService(logger logger.Logger, db interfaces.CurrencyRepository,
 		logger:   logger,
 		db:       db,
 		notifier: notifier,
+		limits:   cache.NewCache(limitsTTL),
 		exchanges: lo.SliceToMap(exchanges, func(exchange interfaces.CurrencyFetcher) (string, interfaces.CurrencyFetcher) {
 			return exchange.GetExchangeName(), exchange
 		}),
@@ -39,7 +61,76 @@ type currencyService struct {
 	logger    logger.Logger
 	db        interfaces.CurrencyRepository
 	exchanges map[string]interfaces.CurrencyFetcher
+	weights   map[string]float64
+	breakers  map[string]*breaker.Breaker
 	notifier  interfaces.SwapNotifier
+	deadline  time.Duration
+	cache     *cache.Cache
+	cacheTTL  time.Duration
+	limits    *cache.Cache
+	precision int
+	inFlight  singleflight.Group[quotesCall]
+	// splitBuckets is how many parts a split quote cuts the amount in
+	splitBuckets  int
+	splitMaxParts int
+}
+
+// WithQuotesCache keeps the quotes for ttl, shared by the requests of the same
+// pair and rate mode whose amounts match to the precision significant digits.
+func (cs *currencyService) WithQuotesCache(ttl time.Duration, precision int) *currencyService {
+	if ttl <= 0 {
+		return cs
+	}
+	cs.cache = cache.NewCache(ttl)
+	cs.cacheTTL = ttl
+	cs.precision = precision
+	return cs
+}
+
+// WithQuotesDeadline bounds how long the quotes wait for the exchanges, the
+// ones still pending are reported as timed out.
+func (cs *currencyService) WithQuotesDeadline(deadline time.Duration) *currencyService {
+	cs.deadline = deadline
+	return cs
+}
+
+// WithSplit sets how many buckets a split quote cuts the amount in, and over
+// how many exchanges at most it spreads them.
+func (cs *currencyService) WithSplit(buckets, maxParts int) *currencyService {
+	cs.splitBuckets = buckets
+	cs.splitMaxParts = maxParts
+	return cs
+}
+
+// WithWeights sets the factor applied to each exchange payout when ranking
+// the quotes. Exchanges without weight count as 1.
+func (cs *currencyService) WithWeights(weights map[string]float64) *currencyService {
+	cs.weights = weights
+	return cs
+}
+
+func (cs *currencyService) getWeight(exchange string) float64 {
+	if weight, ok := cs.weights[exchange]; ok {
+		return weight
+	}
+	return 1
+}
+
+// WithBreakers sets the circuit breaker guarding each exchange in the quotes
+// fan-out. Exchanges without breaker are always called.
+func (cs *currencyService) WithBreakers(breakers map[string]*breaker.Breaker) *currencyService {
+	cs.breakers = breakers
+	return cs
+}
+
+// allow asks the exchange breaker for a call, the returned Done must get the
+// call outcome.
+func (cs *currencyService) allow(exchange string) (breaker.Done, error) {
+	guard, ok := cs.breakers[exchange]
+	if !ok {
+		return func(bool) {}, nil
+	}
+	return guard.Allow()
 }
 
 func (cs *currencyService) GetCurrencies(ctx context.Context, filters models.Filters,
@@ -55,40 +146,330 @@ func (cs *currencyService) GetCurrencies(ctx context.Context, filters models.Fil
 	return currencies, nil
 }
 
-func (cs *currencyService) GetQuotes(ctx context.Context, from, to models.NetworkPair,
-	amount float64) ([]models.Quote, *apierrors.ApiError) {
-	cs.logger.Infof(ctx, "Getting quote for %s to %s with amount %f", from, to, amount)
+func (cs *currencyService) GetCurrency(ctx context.Context, symbol string) (models.Currency, *apierrors.ApiError) {
+	cs.logger.Infof(ctx, "Getting currency %s", symbol)
+
+	currency, err := cs.db.GetCurrency(ctx, symbol)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error getting currency: %+v", err)
+		return models.Currency{}, err
+	}
+
+	return currency, nil
+}
+
+// GetPriceHistory returns the last candles of the currency along with its
+// latest price and change over the previous day.
+func (cs *currencyService) GetPriceHistory(ctx context.Context, symbol string, interval models.CandleInterval,
+	limit int) (models.PriceHistory, *apierrors.ApiError) {
+	cs.logger.Infof(ctx, "Getting %d %s candles of %s", limit, interval, symbol)
+
+	currency, err := cs.db.GetCurrency(ctx, symbol)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error getting currency: %+v", err)
+		return models.PriceHistory{}, err
+	}
+
+	snapshot, err := cs.db.GetLatestPriceSnapshot(ctx, symbol)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error getting price snapshot: %+v", err)
+		return models.PriceHistory{}, err
+	}
+
+	candles, err := cs.db.GetCandles(ctx, symbol, interval, limit)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error getting candles: %+v", err)
+		return models.PriceHistory{}, err
+	}
+
+	return models.PriceHistory{
+		Symbol:    symbol,
+		Price:     currency.Price,
+		Change24h: snapshot.Change24h,
+		Interval:  interval,
+		Candles:   candles,
+	}, nil
+}
+
+func (cs *currencyService) GetQuotes(ctx context.Context, request models.QuoteRequest,
+	rank models.RankStrategy) (models.QuoteResult, *apierrors.ApiError) {
+	cs.logger.Infof(ctx, "Getting %s quote for %s to %s with %s amount %f ranked by %s", request.Rate,
+		request.From, request.To, request.AmountType, request.Amount, rank)
 
-	currLookup, err := cs.getPairs(ctx, from, to)
+	result, err := cs.getQuotes(ctx, request)
 	if err != nil {
-		return []models.Quote{}, err
+		return models.QuoteResult{}, err
+	}
+
+	return result.Rank(rank, cs.getWeight), nil
+}
+
+// GetSplitQuote quotes every multiple of a bucket of the amount, and spreads
+// the buckets over at most parts exchanges to receive the most in total.
+func (cs *currencyService) GetSplitQuote(ctx context.Context, request models.QuoteRequest,
+	parts int) (models.SplitQuote, *apierrors.ApiError) {
+	cs.logger.Infof(ctx, "Getting %s split quote for %s to %s with amount %f over %d parts", request.Rate,
+		request.From, request.To, request.Amount, parts)
+
+	if request.AmountType.IsReverse() {
+		return models.SplitQuote{}, apierrors.NewApiError(apierrors.BadRequest,
+			errors.New("split quotes only fix the amount sent"))
+	}
+	if parts == 0 {
+		parts = cs.splitMaxParts
+	}
+	if parts < 1 || parts > cs.splitMaxParts {
+		return models.SplitQuote{}, apierrors.NewApiError(apierrors.BadRequest,
+			fmt.Errorf("a split quote takes from 1 to %d parts", cs.splitMaxParts))
+	}
+
+	buckets := make([][]models.Quote, cs.splitBuckets)
+	errs := make([]*apierrors.ApiError, cs.splitBuckets)
+	wg := sync.WaitGroup{}
+	for k := range buckets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bucketRequest := request
+			bucketRequest.Amount = request.Amount * float64(k+1) / float64(len(buckets))
+			// Only the whole amount was asked for, the smaller buckets would
+			// skew the quote history
+			bucketCtx := ctx
+			if k < len(buckets)-1 {
+				bucketCtx = withoutQuoteRecords(ctx)
+			}
+			result, err := cs.getQuotes(bucketCtx, bucketRequest)
+			if err != nil {
+				cs.logger.Infof(ctx, "No quotes for %f: %v", bucketRequest.Amount, err)
+				errs[k] = err
+				return
+			}
+			buckets[k] = result.Quotes
+		}()
+	}
+	wg.Wait()
+
+	split, ok := models.NewSplitQuote(buckets, parts)
+	if !ok {
+		// The whole amount tells best why nothing could be quoted
+		if err := errs[len(errs)-1]; err != nil {
+			return models.SplitQuote{}, err
+		}
+		return models.SplitQuote{}, apierrors.NewApiError(apierrors.NotFound,
+			fmt.Errorf("no exchanges quote %f %s to %s", request.Amount, request.From, request.To))
+	}
+
+	return split, nil
+}
+
+func (cs *currencyService) getQuotes(ctx context.Context, request models.QuoteRequest,
+) (models.QuoteResult, *apierrors.ApiError) {
+	key := quotesKey(request, cs.precision)
+	// Fixed rate ids are handed to a single caller, they are neither cached nor
+	// shared
+	if request.Rate.IsFixed() {
+		return cs.fetchQuotes(ctx, request, key).scaledTo(request.Amount)
+	}
+	if cs.cache != nil {
+		if cached, ok := cs.cache.Get(key); ok {
+			cs.logger.Debugf(ctx, "Quotes for %s served from cache", key)
+			return cached.(quotesCall).scaledTo(request.Amount)
+		}
 	}
 
-	return cs.getQuotesFromAllExchanges(ctx, from, to, amount, currLookup), nil
+	// The fan-out is shared, so it must outlive the caller that started it
+	call, _ := cs.inFlight.Do(key, func() quotesCall {
+		return cs.fetchQuotes(context.WithoutCancel(ctx), request, key)
+	})
+
+	return call.scaledTo(request.Amount)
 }
 
-func (cs *currencyService) getQuotesFromAllExchanges(ctx context.Context, from, to models.NetworkPair, amount float64,
-	lookup map[models.NetworkPair]models.Currency) []models.Quote {
+// fetchQuotes asks every exchange for the pair, and looks for routes through
+// a bridge asset when none quotes it directly. The deadline covers both.
+func (cs *currencyService) fetchQuotes(ctx context.Context, request models.QuoteRequest, key string) quotesCall {
+	call := quotesCall{amount: request.Amount}
+
+	currLookup, err := cs.getPairs(ctx, request.From, request.To)
+	if err != nil {
+		call.err = err
+		return call
+	}
+
+	if cs.deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cs.deadline)
+		defer cancel()
+	}
+
+	call.result, call.err = cs.getQuotesFromAllExchanges(ctx, request, currLookup)
+	// Fixed rates can't be routed as the second leg rate would expire before
+	// the first one settles.
+	if call.err == nil && len(call.result.Quotes) == 0 && !request.Rate.IsFixed() {
+		call.result = call.result.WithRoutes(cs.getRoutes(ctx, request))
+	}
+
+	if cs.cache != nil && call.err == nil && !call.result.IsEmpty() && !request.Rate.IsFixed() {
+		cs.cache.Set(key, call, cs.cacheTTL)
+	}
 
+	return call
+}
+
+func (cs *currencyService) getQuotesFromAllExchanges(ctx context.Context, request models.QuoteRequest,
+	lookup map[models.NetworkPair]models.Currency) (models.QuoteResult, *apierrors.ApiError) {
+	exchanges := cs.getPairExchanges(request.From, request.To, lookup)
 	quotes := []models.Quote{}
+	outOfLimits := map[string]models.Limits{}
+	diagnostics := cs.getUnsupportedDiagnostics(exchanges)
+	cutOff := false
+	mu := sync.Mutex{}
 	wg := sync.WaitGroup{}
-	for _, exchange := range cs.exchanges {
+	start := time.Now()
+	for _, exchange := range exchanges {
 		wg.Add(1)
-		go func(quotes *[]models.Quote) {
+		go func() {
 			defer wg.Done()
-			quote, err := exchange.GetQuote(ctx, from, to, amount)
+			name := exchange.GetExchangeName()
+			done, guardErr := cs.allow(name)
+			if guardErr != nil {
+				cs.logger.Warningf(ctx, "Skipping %s: %v", name, guardErr)
+				mu.Lock()
+				defer mu.Unlock()
+				diagnostics[name] = newErrorDiagnostic(name, 0, guardErr)
+				return
+			}
+
+			exchangeRequest := request
+			exchangeRequest.From, exchangeRequest.To = toExchangePairs(name, request.From, request.To, lookup)
+			awaitLimits := cs.getLimitsAsync(ctx, exchange, exchangeRequest.From, exchangeRequest.To, request.Rate)
+			quote, err := exchange.GetQuote(ctx, exchangeRequest)
+			done(err == nil || !isExchangeFailure(err))
+			limits := cs.getQuoteLimits(exchange, exchangeRequest, quote, awaitLimits)
+			latency := time.Since(start)
+
+			mu.Lock()
+			defer mu.Unlock()
+			// The response is gone already, it was reported as timed out
+			if cutOff {
+				return
+			}
 			if err != nil {
 				cs.logger.Error(ctx, err)
+				diagnostics[name] = newErrorDiagnostic(name, latency, err)
+				// Limits bound the amount sent, unknown on failed reverse quotes
+				if !request.AmountType.IsReverse() && !limits.Contains(request.Amount) {
+					outOfLimits[name] = limits
+					diagnostics[name] = diagnostics[name].WithErrorCategory(models.ErrorCategoryOutOfLimits)
+				}
 				return
 			}
-			if !quote.IsEmpty() {
-				*quotes = append(*quotes, quote.UpdateFromPrice(amount, lookup))
+			if quote.IsEmpty() {
+				diagnostics[name] = models.NewExchangeDiagnostic(name, models.ExchangeStatusUnsupported, latency)
+				return
 			}
-		}(&quotes)
+
+			quote.From, quote.To = request.From, request.To
+			quotes = append(quotes, quote.UpdateFromPrice(quote.FromAmount, lookup).
+				WithLimits(limits, quote.FromAmount).
+				WithWarnings(lookup))
+			diagnostics[name] = models.NewExchangeDiagnostic(name, models.ExchangeStatusOk, latency)
+		}()
 	}
-	wg.Wait()
+	waitOrDone(ctx, &wg)
+
+	mu.Lock()
+	defer mu.Unlock()
+	cutOff = true
+	for _, exchange := range exchanges {
+		name := exchange.GetExchangeName()
+		if _, ok := diagnostics[name]; !ok {
+			diagnostics[name] = models.NewExchangeDiagnostic(name, models.ExchangeStatusTimeout, time.Since(start)).
+				WithErrorCategory(models.ErrorCategoryTimeout)
+		}
+	}
+
+	result := models.NewQuoteResult(quotes, diagnostics)
+	cs.recordQuotes(ctx, request, result)
+	if len(quotes) == 0 && len(outOfLimits) > 0 {
+		return models.QuoteResult{}, models.NewOutOfLimitsError(request.Amount, outOfLimits)
+	}
+
+	return result, nil
+}
+
+type skipQuoteRecordsKey struct{}
+
+// withoutQuoteRecords keeps the fan-outs started with the context out of the
+// quote history. Requests coalescing with them aren't recorded either.
+func withoutQuoteRecords(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipQuoteRecordsKey{}, true)
+}
+
+// recordQuotes keeps the fan-out in the quote history, in the background so
+// the quotes don't wait for the database.
+func (cs *currencyService) recordQuotes(ctx context.Context, request models.QuoteRequest,
+	result models.QuoteResult) {
+	if skip, _ := ctx.Value(skipQuoteRecordsKey{}).(bool); skip {
+		return
+	}
+	records := models.NewQuoteRecords(request, result)
+	go func() {
+		if err := cs.db.InsertQuoteRecords(context.WithoutCancel(ctx), records); err != nil {
+			cs.logger.Warningf(ctx, "Error recording quotes: %+v", err)
+		}
+	}()
+}
+
+// GetQuoteAnalytics compares how the exchanges quoted the pair over the window.
+func (cs *currencyService) GetQuoteAnalytics(ctx context.Context, from, to models.NetworkPair,
+	window time.Duration) (models.PairAnalytics, *apierrors.ApiError) {
+	cs.logger.Infof(ctx, "Getting quote analytics for %s to %s over %s", from, to, window)
+
+	since := time.Now().Add(-window)
+	stats, err := cs.db.GetExchangeStats(ctx, from, to, since)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error getting exchange stats: %+v", err)
+		return models.PairAnalytics{}, err
+	}
+
+	return models.PairAnalytics{
+		From:      from,
+		To:        to,
+		Since:     since,
+		Exchanges: stats,
+	}, nil
+}
+
+// getUnsupportedDiagnostics reports the exchanges left out of the fan-out as
+// not supporting the pair.
+func (cs *currencyService) getUnsupportedDiagnostics(
+	pairExchanges []interfaces.CurrencyFetcher) map[string]models.ExchangeDiagnostic {
+	diagnostics := map[string]models.ExchangeDiagnostic{}
+	for name := range cs.exchanges {
+		diagnostics[name] = models.NewExchangeDiagnostic(name, models.ExchangeStatusUnsupported, 0)
+	}
+	for _, exchange := range pairExchanges {
+		delete(diagnostics, exchange.GetExchangeName())
+	}
+	return diagnostics
+}
+
+// getPairExchanges returns the exchanges that list both sides of the pair.
+func (cs *currencyService) getPairExchanges(from, to models.NetworkPair,
+	lookup map[models.NetworkPair]models.Currency) []interfaces.CurrencyFetcher {
+	fromCurr, toCurr := lookup[from], lookup[to]
+	return lo.Filter(lo.Values(cs.exchanges), func(exchange interfaces.CurrencyFetcher, _ int) bool {
+		name := exchange.GetExchangeName()
+		return fromCurr.IsSupportedBy(from, name) && toCurr.IsSupportedBy(to, name)
+	})
+}
 
-	return quotes
+// toExchangePairs returns the pairs as the exchange lists them.
+func toExchangePairs(exchange string, from, to models.NetworkPair,
+	lookup map[models.NetworkPair]models.Currency) (models.NetworkPair, models.NetworkPair) {
+	fromCurr, toCurr := lookup[from], lookup[to]
+	return fromCurr.GetExchangePair(from, exchange), toCurr.GetExchangePair(to, exchange)
 }
 
 func (cs *currencyService) getPairs(ctx context.Context, from, to models.NetworkPair,
@@ -99,14 +480,7 @@ func (cs *currencyService) getPairs(ctx context.Context, from, to models.Network
 		return nil, err
 	}
 
-	// Grab all networks:
-	fetchedNetworkLookup := map[models.NetworkPair]models.Currency{}
-	for _, currency := range currencies {
-		networks := currency.GetNetworks()
-		for _, network := range networks {
-			fetchedNetworkLookup[network] = currency
-		}
-	}
+	fetchedNetworkLookup := toNetworkLookup(currencies)
 
 	// Check if any of the symbols don't exist and get the not found string symbols
 	notFoundPairs := []models.NetworkPair{}
@@ -127,6 +501,16 @@ func (cs *currencyService) getPairs(ctx context.Context, from, to models.Network
 	return fetchedNetworkLookup, nil
 }
 
+func toNetworkLookup(currencies []models.Currency) map[models.NetworkPair]models.Currency {
+	lookup := map[models.NetworkPair]models.Currency{}
+	for _, currency := range currencies {
+		for _, network := range currency.GetNetworks() {
+			lookup[network] = currency
+		}
+	}
+	return lookup
+}
+
 func (cs *currencyService) GetSwap(ctx context.Context, id string) (models.Swap, *apierrors.ApiError) {
 	cs.logger.Infof(ctx, "Getting swap with id: %s", id)
 
@@ -136,47 +520,252 @@ func (cs *currencyService) GetSwap(ctx context.Context, id string) (models.Swap,
 		return models.Swap{}, err
 	}
 
-	return swap, nil
+	// The swap is still answered without links if its currencies can't be read
+	currencies, err := cs.db.GetCurrenciesByPairs(ctx, swap.From, swap.To)
+	if err != nil {
+		cs.logger.Warningf(ctx, "Error getting swap currencies: %+v", err)
+		return swap, nil
+	}
+
+	lookup := toNetworkLookup(currencies)
+	if swap.IsSplit() {
+		children, err := cs.db.GetChildSwaps(ctx, swap.Id)
+		if err != nil {
+			cs.logger.Errorf(ctx, "Error getting child swaps: %+v", err)
+			return models.Swap{}, err
+		}
+		return cs.withChildLinks(*swap.WithChildren(children), lookup), nil
+	}
+
+	return *swap.WithExplorerLinks(lookup[swap.From], lookup[swap.To]), nil
 }
 
 func (cs *currencyService) InsertSwap(ctx context.Context, swap models.Swap) (models.Swap, *apierrors.ApiError) {
 	cs.logger.Infof(ctx, "Inserting swap", swap)
 
-	pairs, err := cs.getPairs(ctx, swap.From, swap.To)
+	pairs, err := cs.getValidPairs(ctx, swap)
 	if err != nil {
-		cs.logger.Errorf(ctx, "Error getting swap: %+v", err)
 		return models.Swap{}, err
 	}
 
-	if err := swap.HasValidAddress(pairs[swap.To]); err != nil {
-		cs.logger.Errorf(ctx, "Error validating address: %+v", err)
+	swap, err = cs.createExchangeSwap(ctx, swap, pairs)
+	if err != nil {
 		return models.Swap{}, err
 	}
 
-	// TODO: Go to the requested exchange and create the swap, inform the current swap
-	// TODO: Add transactioner, the billing should be somewhere else
-	newSwap, err := cs.db.InsertSwap(ctx, *swap.WithBillingConditions("XYZ-address", "xdsq2324adgs", 10))
+	// TODO: Add transactioner, the swap is already open on the exchange if the insert fails
+	newSwap, err := cs.db.InsertSwap(ctx, swap)
 	if err != nil {
 		cs.logger.Errorf(ctx, "Error inserting swap: %+v", err)
 		return models.Swap{}, err
 	}
 
-	if err := cs.notifier.NotifySwap(ctx, swap); err != nil {
+	if err := cs.notifier.NotifySwap(ctx, newSwap); err != nil {
 		cs.logger.Errorf(ctx, "Error notifying swap: %+v", err)
 		return models.Swap{}, err
 	}
 
-	return newSwap, nil
+	return *newSwap.WithExplorerLinks(pairs[swap.From], pairs[swap.To]).WithWarnings(pairs), nil
+}
+
+// InsertSplitSwap opens every part of the split swap on its exchange, then
+// stores them under the split swap. Each part is tracked on its own.
+func (cs *currencyService) InsertSplitSwap(ctx context.Context, swap models.Swap,
+) (models.Swap, *apierrors.ApiError) {
+	cs.logger.Infof(ctx, "Inserting split swap in %d parts", len(swap.Children))
+
+	if len(swap.Children) == 0 || len(swap.Children) > cs.splitMaxParts {
+		return models.Swap{}, apierrors.NewApiError(apierrors.BadRequest,
+			fmt.Errorf("a split swap takes from 1 to %d parts", cs.splitMaxParts))
+	}
+
+	pairs, err := cs.getValidPairs(ctx, swap)
+	if err != nil {
+		return models.Swap{}, err
+	}
+
+	// The parts opened before a failing one are left unpaid on their exchange
+	children := make([]models.Swap, len(swap.Children))
+	for i, child := range swap.Children {
+		if children[i], err = cs.createExchangeSwap(ctx, child, pairs); err != nil {
+			return models.Swap{}, err
+		}
+	}
+
+	newSwap, err := cs.db.InsertSplitSwap(ctx, *swap.WithChildren(children))
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error inserting split swap: %+v", err)
+		return models.Swap{}, err
+	}
+
+	for _, child := range newSwap.Children {
+		if err := cs.notifier.NotifySwap(ctx, child); err != nil {
+			cs.logger.Errorf(ctx, "Error notifying swap: %+v", err)
+			return models.Swap{}, err
+		}
+	}
+
+	return cs.withChildLinks(newSwap, pairs), nil
+}
+
+// getValidPairs returns the currencies of the swap once its addresses and
+// extra ids are checked against them.
+func (cs *currencyService) getValidPairs(ctx context.Context, swap models.Swap,
+) (map[models.NetworkPair]models.Currency, *apierrors.ApiError) {
+	pairs, err := cs.getPairs(ctx, swap.From, swap.To)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error getting swap: %+v", err)
+		return nil, err
+	}
+
+	if err := swap.HasValidAddress(pairs[swap.To]); err != nil {
+		cs.logger.Errorf(ctx, "Error validating address: %+v", err)
+		return nil, err
+	}
+
+	if err := swap.HasValidExtraIds(pairs[swap.From], pairs[swap.To]); err != nil {
+		cs.logger.Errorf(ctx, "Error validating extra id: %+v", err)
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+// createExchangeSwap opens the swap on its exchange once the amount is checked
+// against the exchange limits. The exchange is sent the pairs as it lists them.
+func (cs *currencyService) createExchangeSwap(ctx context.Context, swap models.Swap,
+	pairs map[models.NetworkPair]models.Currency) (models.Swap, *apierrors.ApiError) {
+	creator, err := cs.getSwapCreator(swap.Exchange)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error getting swap creator: %+v", err)
+		return models.Swap{}, err
+	}
+
+	listed := swap
+	listed.From, listed.To = toExchangePairs(swap.Exchange, swap.From, swap.To, pairs)
+	limits := cs.getLimits(ctx, cs.exchanges[swap.Exchange], listed.From, listed.To, swap.GetRateMode())
+	if err := limits.Validate(swap.Exchange, swap.PayinAmount); err != nil {
+		cs.logger.Errorf(ctx, "Error validating amount: %+v", err)
+		return models.Swap{}, err
+	}
+
+	exchangeSwap, err := creator.CreateSwap(ctx, listed)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error creating swap on %s: %+v", swap.Exchange, err)
+		return models.Swap{}, err
+	}
+
+	return *swap.WithExchangeSwap(exchangeSwap), nil
+}
+
+// withChildLinks sets the explorer links and warnings of every part, the
+// split swap has no deposit address of its own.
+func (cs *currencyService) withChildLinks(swap models.Swap, pairs map[models.NetworkPair]models.Currency) models.Swap {
+	children := make([]models.Swap, len(swap.Children))
+	for i, child := range swap.Children {
+		children[i] = *child.WithExplorerLinks(pairs[child.From], pairs[child.To]).WithWarnings(pairs)
+	}
+	swap.Children = children
+	return swap
+}
+
+func (cs *currencyService) getSwapCreator(name string) (interfaces.SwapCreator, *apierrors.ApiError) {
+	exchange, ok := cs.exchanges[name]
+	if !ok {
+		return nil, apierrors.NewApiError(apierrors.BadRequest, fmt.Errorf("exchange %s not found", name))
+	}
+
+	creator, ok := exchange.(interfaces.SwapCreator)
+	if !ok {
+		return nil, apierrors.NewApiError(apierrors.BadRequest,
+			fmt.Errorf("exchange %s does not support swap creation", name))
+	}
+
+	return creator, nil
 }
 
 func (cs *currencyService) ProcessSwap(ctx context.Context, swap models.Swap) *apierrors.ApiError {
-	cs.logger.Infof(ctx, "Updating swap")
+	cs.logger.Infof(ctx, "Processing swap %s on %s", swap.Id, swap.Exchange)
+
+	fetcher, err := cs.getStatusFetcher(swap.Exchange)
+	if err != nil {
+		cs.logger.Warningf(ctx, "Swap %s can't be tracked: %+v", swap.Id, err)
+		return cs.stopTracking(ctx, swap, fmt.Sprintf("status not tracked on %s", swap.Exchange))
+	}
 
-	err := cs.db.UpdateSwap(ctx, *swap.Complete())
+	status, err := fetcher.GetSwapStatus(ctx, swap.ExchangeId)
 	if err != nil {
+		cs.logger.Errorf(ctx, "Error fetching swap status: %+v", err)
+		return cs.trackLater(ctx, swap)
+	}
+	if !status.IsKnown() {
+		cs.logger.Warningf(ctx, "Swap %s keeps status %s: %s", swap.Id, swap.Status, status.Reason)
+	}
+
+	if err := cs.db.UpdateSwap(ctx, *swap.WithExchangeStatus(status)); err != nil {
 		cs.logger.Errorf(ctx, "Error updating swap: %+v", err)
 		return err
 	}
 
+	if swap.ParentId != "" {
+		if err := cs.rollUpSplitSwap(ctx, swap.ParentId); err != nil {
+			cs.logger.Errorf(ctx, "Error rolling up split swap %s: %+v", swap.ParentId, err)
+			return err
+		}
+	}
+
+	if swap.IsFinished() {
+		cs.logger.Infof(ctx, "Swap %s finished with status %s", swap.Id, swap.Status)
+		return nil
+	}
+
+	return cs.trackLater(ctx, swap)
+}
+
+// trackLater checks the swap status again after a while, until the swap is too
+// old to keep being tracked.
+func (cs *currencyService) trackLater(ctx context.Context, swap models.Swap) *apierrors.ApiError {
+	if !swap.CreatedAt.IsZero() && time.Since(swap.CreatedAt) > swapTrackingTimeout {
+		cs.logger.Warningf(ctx, "Giving up on swap %s in status %s", swap.Id, swap.Status)
+		return cs.stopTracking(ctx, swap, fmt.Sprintf("status not tracked after %s", swapTrackingTimeout))
+	}
+	return cs.notifier.NotifySwapLater(ctx, swap, statusCheckInterval)
+}
+
+func (cs *currencyService) stopTracking(ctx context.Context, swap models.Swap, reason string) *apierrors.ApiError {
+	if err := cs.db.UpdateSwap(ctx, *swap.StopTracking(reason)); err != nil {
+		cs.logger.Errorf(ctx, "Error updating swap: %+v", err)
+		return err
+	}
 	return nil
 }
+
+// rollUpSplitSwap updates the split swap with the statuses of its parts.
+func (cs *currencyService) rollUpSplitSwap(ctx context.Context, id string) *apierrors.ApiError {
+	swap, err := cs.db.GetSwap(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	children, err := cs.db.GetChildSwaps(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return cs.db.UpdateSwap(ctx, *swap.WithChildren(children))
+}
+
+func (cs *currencyService) getStatusFetcher(name string) (interfaces.SwapStatusFetcher, *apierrors.ApiError) {
+	exchange, ok := cs.exchanges[name]
+	if !ok {
+		return nil, apierrors.NewApiError(apierrors.BadRequest, fmt.Errorf("exchange %s not found", name))
+	}
+
+	fetcher, ok := exchange.(interfaces.SwapStatusFetcher)
+	if !ok {
+		return nil, apierrors.NewApiError(apierrors.BadRequest,
+			fmt.Errorf("exchange %s does not support status tracking", name))
+	}
+
+	return fetcher, nil
+}
@@ -0,0 +1,282 @@
+package currencies
+
+import (
+	"context"
+	"cryptoswap/internal/lib/apierrors"
+	"cryptoswap/internal/lib/breaker"
+	"cryptoswap/internal/lib/httpclient"
+	"cryptoswap/internal/lib/httpclient/httpclienttest"
+	"cryptoswap/internal/services/interfaces"
+	"cryptoswap/internal/services/models"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// fakeRepository serves the currencies and keeps the quote records and the
+// swap updates, the other calls aren't expected.
+type fakeRepository struct {
+	interfaces.CurrencyRepository
+	currencies []models.Currency
+	mu         sync.Mutex
+	records    []models.QuoteRecord
+	swaps      []models.Swap
+}
+
+func (f *fakeRepository) UpdateSwap(_ context.Context, swap models.Swap) *apierrors.ApiError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.swaps = append(f.swaps, swap)
+	return nil
+}
+
+func (f *fakeRepository) GetCurrenciesByPairs(context.Context, ...models.NetworkPair,
+) ([]models.Currency, *apierrors.ApiError) {
+	return f.currencies, nil
+}
+
+func (f *fakeRepository) InsertQuoteRecords(_ context.Context, records []models.QuoteRecord) *apierrors.ApiError {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.records = append(f.records, records...)
+	return nil
+}
+
+func (f *fakeRepository) getRecords() []models.QuoteRecord {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.records
+}
+
+// waitForRecords waits for the records inserted in the background, and a bit
+// longer for any unexpected one.
+func (f *fakeRepository) waitForRecords() []models.QuoteRecord {
+	deadline := time.Now().Add(time.Second)
+	for len(f.getRecords()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	return f.getRecords()
+}
+
+func newPairLookup(exchange string, pairs ...models.NetworkPair) map[models.NetworkPair]models.Currency {
+	lookup := map[models.NetworkPair]models.Currency{}
+	for _, pair := range pairs {
+		lookup[pair] = models.NewCurrency(exchange, pair.Network, pair.Symbol, pair.Symbol, "", "", true)
+	}
+	return lookup
+}
+
+func TestGetQuotesFromAllExchanges_BreakerIgnoresRejections(t *testing.T) {
+	btc, eth := models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("eth", "eth")
+	lookup := newPairLookup("A", btc, eth)
+	request := models.NewQuoteRequest(btc, eth, 1, models.RateFloating)
+
+	tests := []struct {
+		status int
+		state  breaker.State
+	}{
+		{status: http.StatusBadRequest, state: breaker.StateClosed},
+		{status: http.StatusServiceUnavailable, state: breaker.StateOpen},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			exchange := &fakeExchange{name: "A", quoteErr: apierrors.NewApiError(apierrors.InternalServer,
+				&httpclient.StatusError{StatusCode: tt.status})}
+			guard := breaker.NewBreaker(breaker.Config{FailureThreshold: 2, OpenTimeout: time.Minute})
+			cs := newTestService(exchange).WithBreakers(map[string]*breaker.Breaker{"A": guard})
+			cs.db = &fakeRepository{}
+
+			for range 3 {
+				cs.getQuotesFromAllExchanges(context.Background(), request, lookup)
+			}
+			if state := guard.GetState(); state != tt.state {
+				t.Errorf("expected the circuit %s, got %s", tt.state, state)
+			}
+		})
+	}
+}
+
+// quoteRecorder is a fake exchange keeping the last quote request.
+type quoteRecorder struct {
+	*fakeExchange
+	request models.QuoteRequest
+}
+
+func (f *quoteRecorder) GetQuote(ctx context.Context, request models.QuoteRequest,
+) (models.Quote, *apierrors.ApiError) {
+	f.request = request
+	quote, err := f.fakeExchange.GetQuote(ctx, request)
+	quote.From, quote.To = request.From, request.To
+	return quote, err
+}
+
+func TestGetQuotesFromAllExchanges_SendsExchangeTickers(t *testing.T) {
+	contract := "0x1234567890abcdef1234567890abcdef12345678"
+	usdt := models.NewNetworkPair(models.GetTokenSymbol("usdt", contract), "eth")
+	btc := models.NewNetworkPair("btc", "btc")
+	lookup := newPairLookup("A", btc)
+	lookup[usdt] = models.NewCurrency("A", usdt.Network, usdt.Symbol, "Tether", "", "", true).
+		WithNetworkInfo(models.NetworkInfo{Contract: contract}.WithTicker("A", "usdt"))
+
+	exchange := &quoteRecorder{fakeExchange: &fakeExchange{name: "A", quote: models.Quote{Exchange: "A",
+		FromAmount: 1, Amount: 30}}}
+	cs := NewCurrencyService(httpclienttest.NewLogger("currency_service"), &fakeRepository{}, nil, exchange)
+
+	result, err := cs.getQuotesFromAllExchanges(context.Background(),
+		models.NewQuoteRequest(usdt, btc, 1, models.RateFloating), lookup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent := exchange.request.From; sent != models.NewNetworkPair("usdt", "eth") {
+		t.Errorf("expected the exchange ticker sent, got %s", sent)
+	}
+	if len(result.Quotes) != 1 || result.Quotes[0].From != usdt || result.Quotes[0].To != btc {
+		t.Errorf("expected the quote on the requested pair, got %+v", result.Quotes)
+	}
+}
+
+func TestGetSplitQuote_RecordsOnlyTheWholeAmount(t *testing.T) {
+	btc, eth := models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("eth", "eth")
+	exchange := &fakeExchange{name: "A", quoteErr: apierrors.NewApiError(apierrors.InternalServer,
+		&httpclient.StatusError{StatusCode: http.StatusBadRequest})}
+	db := &fakeRepository{currencies: lo.Values(newPairLookup("A", btc, eth))}
+	cs := newTestService(exchange).WithSplit(4, 2)
+	cs.db = db
+
+	cs.GetSplitQuote(context.Background(), models.NewQuoteRequest(btc, eth, 1, models.RateFloating), 2)
+
+	records := db.waitForRecords()
+	if calls := exchange.quoteCalls.Load(); calls != 4 {
+		t.Fatalf("expected a quote per bucket, got %d", calls)
+	}
+	if len(records) != 1 {
+		t.Errorf("expected only the whole amount fan-out recorded, got %+v", records)
+	}
+}
+
+func TestGetQuotes_RecordsOnlyTheRequestedPair(t *testing.T) {
+	xmr, doge := models.NewNetworkPair("xmr", "xmr"), models.NewNetworkPair("doge", "doge")
+	btc := models.NewNetworkPair("btc", "btc")
+	exchange := &fakeExchange{name: "A", quoteErr: apierrors.NewApiError(apierrors.InternalServer,
+		&httpclient.StatusError{StatusCode: http.StatusBadRequest})}
+	db := &fakeRepository{currencies: lo.Values(newPairLookup("A", xmr, doge, btc))}
+	cs := newTestService(exchange)
+	cs.db = db
+
+	cs.GetQuotes(context.Background(), models.NewQuoteRequest(xmr, doge, 1, models.RateFloating), models.RankPayout)
+
+	records := db.waitForRecords()
+	// The direct fan-out found no quote, so a route through btc was tried
+	if calls := exchange.quoteCalls.Load(); calls != 2 {
+		t.Fatalf("expected the direct and first leg quotes, got %d", calls)
+	}
+	if len(records) != 1 || records[0].From != xmr || records[0].To != doge {
+		t.Errorf("expected only the requested pair recorded, got %+v", records)
+	}
+}
+
+func TestGetQuotes_FixedRatesAreNotShared(t *testing.T) {
+	btc, eth := models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("eth", "eth")
+	exchange := &fakeExchange{name: "A", quote: models.Quote{Exchange: "A", From: btc, To: eth,
+		FromAmount: 1, Amount: 30, RateId: "rate-1"}}
+	db := &fakeRepository{currencies: lo.Values(newPairLookup("A", btc, eth))}
+	cs := newTestService(exchange).WithQuotesCache(time.Minute, 4)
+	cs.db = db
+
+	for _, rate := range []models.RateMode{models.RateFixed, models.RateFixed, models.RateFloating,
+		models.RateFloating} {
+		if _, err := cs.GetQuotes(context.Background(), models.NewQuoteRequest(btc, eth, 1, rate),
+			models.RankPayout); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	db.waitForRecords()
+
+	if calls := exchange.quoteCalls.Load(); calls != 3 {
+		t.Errorf("expected a fan-out per fixed quote and a cached floating one, got %d", calls)
+	}
+}
+
+// fakeNotifier keeps the swaps scheduled for a later status check.
+type fakeNotifier struct {
+	interfaces.SwapNotifier
+	later []models.Swap
+}
+
+func (f *fakeNotifier) NotifySwapLater(_ context.Context, swap models.Swap, _ time.Duration) *apierrors.ApiError {
+	f.later = append(f.later, swap)
+	return nil
+}
+
+// statusFetcher is a fake exchange answering every swap status call the same.
+type statusFetcher struct {
+	*fakeExchange
+	status models.ExchangeSwapStatus
+	err    *apierrors.ApiError
+}
+
+func (f statusFetcher) GetSwapStatus(context.Context, string) (models.ExchangeSwapStatus, *apierrors.ApiError) {
+	return f.status, f.err
+}
+
+func TestProcessSwap(t *testing.T) {
+	unavailable := apierrors.NewApiError(apierrors.InternalServer,
+		&httpclient.StatusError{StatusCode: http.StatusServiceUnavailable})
+	tests := []struct {
+		name      string
+		fetcher   statusFetcher
+		createdAt time.Time
+		status    string
+		tracked   bool
+	}{
+		{
+			name:    "known status",
+			fetcher: statusFetcher{status: models.ExchangeSwapStatus{Status: models.SwapStatusExchanging}},
+			status:  models.SwapStatusExchanging,
+			tracked: true,
+		},
+		{
+			name: "unknown status keeps the current one",
+			fetcher: statusFetcher{status: models.NewExchangeSwapStatus("verifying",
+				map[string]string{"waiting": models.SwapStatusPending})},
+			status:  models.SwapStatusConfirming,
+			tracked: true,
+		},
+		{
+			name:      "status errors are retried",
+			fetcher:   statusFetcher{err: unavailable},
+			createdAt: time.Now().Add(-time.Hour),
+			tracked:   true,
+		},
+		{
+			name:      "old swaps stop being tracked",
+			fetcher:   statusFetcher{err: unavailable},
+			createdAt: time.Now().Add(-swapTrackingTimeout - time.Hour),
+			status:    models.SwapStatusConfirming,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fetcher.fakeExchange = &fakeExchange{name: "A"}
+			db, notifier := &fakeRepository{}, &fakeNotifier{}
+			cs := NewCurrencyService(httpclienttest.NewLogger("currency_service"), db, notifier, tt.fetcher)
+
+			swap := models.Swap{Id: "swap", Exchange: "A", Status: models.SwapStatusConfirming,
+				CreatedAt: tt.createdAt}
+			if err := cs.ProcessSwap(context.Background(), swap); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tracked := len(notifier.later) == 1; tracked != tt.tracked {
+				t.Errorf("expected tracked=%t, got %d checks scheduled", tt.tracked, len(notifier.later))
+			}
+			if tt.status != "" && (len(db.swaps) != 1 || db.swaps[0].Status != tt.status) {
+				t.Errorf("expected the swap stored with status %s, got %+v", tt.status, db.swaps)
+			}
+		})
+	}
+}
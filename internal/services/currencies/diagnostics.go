@@ -0,0 +1,71 @@
+package currencies
+
+import (
+	"context"
+	"cryptoswap/internal/lib/breaker"
+	"cryptoswap/internal/lib/httpclient"
+	"cryptoswap/internal/services/models"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+func newErrorDiagnostic(exchange string, latency time.Duration, err error) models.ExchangeDiagnostic {
+	category := categorizeError(err)
+	status := models.ExchangeStatusError
+	if category == models.ErrorCategoryTimeout {
+		status = models.ExchangeStatusTimeout
+	}
+	return models.NewExchangeDiagnostic(exchange, status, latency).WithErrorCategory(category)
+}
+
+// categorizeError tells why an exchange call failed, from the errors wrapped
+// by the adapters.
+func categorizeError(err error) string {
+	var statusErr *httpclient.StatusError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, breaker.ErrOpen):
+		return models.ErrorCategoryCircuitOpen
+	case errors.Is(err, breaker.ErrBulkheadFull):
+		return models.ErrorCategoryBulkheadFull
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return models.ErrorCategoryTimeout
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusTooManyRequests:
+		return models.ErrorCategoryRateLimited
+	case errors.As(err, &statusErr) && statusErr.StatusCode >= http.StatusInternalServerError:
+		return models.ErrorCategoryUnavailable
+	case errors.As(err, &statusErr):
+		return models.ErrorCategoryRejected
+	default:
+		return models.ErrorCategoryUnknown
+	}
+}
+
+// isExchangeFailure tells whether an exchange call error counts against its
+// breaker: the exchange was unreachable, too slow, throttling or failing. An
+// exchange rejecting the request is up and answering.
+func isExchangeFailure(err error) bool {
+	switch categorizeError(err) {
+	case models.ErrorCategoryTimeout, models.ErrorCategoryRateLimited, models.ErrorCategoryUnavailable:
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// waitOrDone waits for the group, or until the context is done.
+func waitOrDone(ctx context.Context, wg *sync.WaitGroup) {
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,76 @@
+package currencies
+
+import (
+	"context"
+	"cryptoswap/internal/services/interfaces"
+	"cryptoswap/internal/services/models"
+	"fmt"
+	"time"
+)
+
+// limitsTTL is how long the limits of a pair are reused. Exchanges move them
+// with the market, but far slower than the quotes.
+const limitsTTL = time.Minute
+
+func limitsKey(exchange string, from, to models.NetworkPair, rate models.RateMode) string {
+	return fmt.Sprintf("%s:%s:%s:%s", exchange, from, to, rate)
+}
+
+// getLimits returns the exchange range for the pair, or empty limits when the
+// exchange doesn't expose them or they couldn't be fetched.
+func (cs *currencyService) getLimits(ctx context.Context, exchange interfaces.CurrencyFetcher,
+	from, to models.NetworkPair, rate models.RateMode) models.Limits {
+	fetcher, ok := exchange.(interfaces.LimitFetcher)
+	if !ok {
+		return models.Limits{}
+	}
+
+	key := limitsKey(exchange.GetExchangeName(), from, to, rate)
+	if cached, ok := cs.limits.Get(key); ok {
+		return cached.(models.Limits)
+	}
+
+	limits, err := fetcher.GetLimits(ctx, from, to, rate)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error getting limits from %s: %+v", exchange.GetExchangeName(), err)
+		return models.Limits{}
+	}
+
+	cs.limits.Set(key, limits, limitsTTL)
+	return limits
+}
+
+// getLimitsAsync fetches the limits alongside the quote, the returned func
+// waits for them. Exchanges quoting their limits are only served from cache.
+func (cs *currencyService) getLimitsAsync(ctx context.Context, exchange interfaces.CurrencyFetcher,
+	from, to models.NetworkPair, rate models.RateMode) func() models.Limits {
+	if limiter, ok := exchange.(interfaces.QuoteLimiter); ok && limiter.HasQuoteLimits() {
+		return func() models.Limits {
+			if cached, ok := cs.limits.Get(limitsKey(exchange.GetExchangeName(), from, to, rate)); ok {
+				return cached.(models.Limits)
+			}
+			return models.Limits{}
+		}
+	}
+
+	result := make(chan models.Limits, 1)
+	go func() {
+		result <- cs.getLimits(ctx, exchange, from, to, rate)
+	}()
+	return func() models.Limits {
+		return <-result
+	}
+}
+
+// getQuoteLimits takes the limits carried by the quote when there are, and
+// keeps them for the next fan-outs and swaps.
+func (cs *currencyService) getQuoteLimits(exchange interfaces.CurrencyFetcher, request models.QuoteRequest,
+	quote models.Quote, awaitLimits func() models.Limits) models.Limits {
+	if quote.Limits.IsEmpty() {
+		return awaitLimits()
+	}
+
+	cs.limits.Set(limitsKey(exchange.GetExchangeName(), request.From, request.To, request.Rate), quote.Limits,
+		limitsTTL)
+	return quote.Limits
+}
@@ -0,0 +1,98 @@
+package currencies
+
+import (
+	"context"
+	"cryptoswap/internal/lib/apierrors"
+	"cryptoswap/internal/lib/httpclient/httpclienttest"
+	"cryptoswap/internal/services/interfaces"
+	"cryptoswap/internal/services/models"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeExchange answers every quote and limits call with the same result,
+// counting the calls.
+type fakeExchange struct {
+	name        string
+	quote       models.Quote
+	quoteErr    *apierrors.ApiError
+	limits      models.Limits
+	quoteCalls  atomic.Int32
+	limitsCalls atomic.Int32
+}
+
+func (f *fakeExchange) GetExchangeName() string {
+	return f.name
+}
+
+func (f *fakeExchange) GetCurrencies(context.Context) ([]models.Currency, *apierrors.ApiError) {
+	return nil, nil
+}
+
+func (f *fakeExchange) GetQuote(context.Context, models.QuoteRequest) (models.Quote, *apierrors.ApiError) {
+	f.quoteCalls.Add(1)
+	return f.quote, f.quoteErr
+}
+
+func (f *fakeExchange) GetLimits(context.Context, models.NetworkPair, models.NetworkPair, models.RateMode,
+) (models.Limits, *apierrors.ApiError) {
+	f.limitsCalls.Add(1)
+	return f.limits, nil
+}
+
+// quoteLimiter is a fake exchange whose quotes carry the limits.
+type quoteLimiter struct {
+	*fakeExchange
+}
+
+func (quoteLimiter) HasQuoteLimits() bool {
+	return true
+}
+
+func newTestService(exchanges ...*fakeExchange) *currencyService {
+	fetchers := make([]interfaces.CurrencyFetcher, len(exchanges))
+	for i, exchange := range exchanges {
+		fetchers[i] = exchange
+	}
+	return NewCurrencyService(httpclienttest.NewLogger("currency_service"), nil, nil, fetchers...)
+}
+
+func TestGetLimits_Cached(t *testing.T) {
+	exchange := &fakeExchange{name: "A", limits: models.Limits{Min: 0.01, Max: 2}}
+	cs := newTestService(exchange)
+	btc, eth := models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("eth", "eth")
+
+	for range 3 {
+		if limits := cs.getLimits(context.Background(), exchange, btc, eth, models.RateFloating); limits !=
+			exchange.limits {
+			t.Fatalf("unexpected limits: %+v", limits)
+		}
+	}
+	cs.getLimits(context.Background(), exchange, btc, eth, models.RateFixed)
+
+	if calls := exchange.limitsCalls.Load(); calls != 2 {
+		t.Errorf("expected a call per rate mode, got %d", calls)
+	}
+}
+
+func TestGetQuoteLimits_FromQuote(t *testing.T) {
+	exchange := quoteLimiter{&fakeExchange{name: "A", limits: models.Limits{Min: 5}}}
+	cs := newTestService()
+	request := models.NewQuoteRequest(models.NewNetworkPair("btc", "btc"), models.NewNetworkPair("eth", "eth"),
+		1, models.RateFloating)
+	quote := models.Quote{Limits: models.Limits{Min: 0.01, Max: 2}}
+
+	awaitLimits := cs.getLimitsAsync(context.Background(), exchange, request.From, request.To, request.Rate)
+	if limits := cs.getQuoteLimits(exchange, request, quote, awaitLimits); limits != quote.Limits {
+		t.Errorf("expected the quote limits, got %+v", limits)
+	}
+	if calls := exchange.limitsCalls.Load(); calls != 0 {
+		t.Errorf("expected no limits call, got %d", calls)
+	}
+
+	// The next fan-out falls back to them when the quote fails
+	awaitLimits = cs.getLimitsAsync(context.Background(), exchange, request.From, request.To, request.Rate)
+	if limits := cs.getQuoteLimits(exchange, request, models.Quote{}, awaitLimits); limits != quote.Limits {
+		t.Errorf("expected the cached quote limits, got %+v", limits)
+	}
+}
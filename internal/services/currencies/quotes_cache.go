@@ -0,0 +1,34 @@
+package currencies
+
+import (
+	"cryptoswap/internal/lib/apierrors"
+	"cryptoswap/internal/services/models"
+	"fmt"
+	"strconv"
+)
+
+// quotesCall is a fan-out result, shared by the coalesced requests and kept in
+// the quotes cache.
+type quotesCall struct {
+	amount float64
+	result models.QuoteResult
+	err    *apierrors.ApiError
+}
+
+// quotesKey identifies the requests that can share their quotes. Floating
+// amounts are bucketed to the precision significant digits, fixed ones keep
+// the exact amount as their rate ids are bound to it.
+func quotesKey(request models.QuoteRequest, precision int) string {
+	bucket := strconv.FormatFloat(request.Amount, 'g', -1, 64)
+	if !request.Rate.IsFixed() && precision > 0 {
+		bucket = strconv.FormatFloat(request.Amount, 'g', precision, 64)
+	}
+	return fmt.Sprintf("%s:%s:%s:%s:%s", request.From, request.To, request.Rate, request.AmountType, bucket)
+}
+
+func (c quotesCall) scaledTo(amount float64) (models.QuoteResult, *apierrors.ApiError) {
+	if c.err != nil {
+		return models.QuoteResult{}, c.err
+	}
+	return c.result.ScaledTo(c.amount, amount), nil
+}
@@ -0,0 +1,72 @@
+package currencies
+
+import (
+	"cryptoswap/internal/services/models"
+	"testing"
+)
+
+func TestQuotesKey(t *testing.T) {
+	btc := models.NewNetworkPair("btc", "btc")
+	eth := models.NewNetworkPair("eth", "eth")
+
+	tests := []struct {
+		name  string
+		a, b  models.QuoteRequest
+		equal bool
+	}{
+		{
+			name:  "same bucket",
+			a:     models.NewQuoteRequest(btc, eth, 0.12345, models.RateFloating),
+			b:     models.NewQuoteRequest(btc, eth, 0.12346, models.RateFloating),
+			equal: true,
+		},
+		{
+			name: "other bucket",
+			a:    models.NewQuoteRequest(btc, eth, 0.1234, models.RateFloating),
+			b:    models.NewQuoteRequest(btc, eth, 0.1235, models.RateFloating),
+		},
+		{
+			name: "fixed rate keeps the exact amount",
+			a:    models.NewQuoteRequest(btc, eth, 0.12345, models.RateFixed),
+			b:    models.NewQuoteRequest(btc, eth, 0.12346, models.RateFixed),
+		},
+		{
+			name: "rate mode",
+			a:    models.NewQuoteRequest(btc, eth, 0.1, models.RateFloating),
+			b:    models.NewQuoteRequest(btc, eth, 0.1, models.RateFixed),
+		},
+		{
+			name: "direction",
+			a:    models.NewQuoteRequest(btc, eth, 0.1, models.RateFloating),
+			b:    models.NewQuoteRequest(eth, btc, 0.1, models.RateFloating),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := quotesKey(tt.a, 4), quotesKey(tt.b, 4)
+			if (a == b) != tt.equal {
+				t.Errorf("expected keys equal=%t, got %q and %q", tt.equal, a, b)
+			}
+		})
+	}
+}
+
+func TestQuotesCall_ScaledTo(t *testing.T) {
+	call := quotesCall{
+		amount: 1,
+		result: models.QuoteResult{Quotes: []models.Quote{
+			{FromAmount: 1, Amount: 30, Limits: models.Limits{Min: 1.5}},
+		}},
+	}
+
+	result, err := call.scaledTo(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quote := result.Quotes[0]; quote.FromAmount != 2 || quote.Amount != 60 || !quote.WithinLimits {
+		t.Errorf("unexpected scaled quote: %+v", quote)
+	}
+	if call.result.Quotes[0].Amount != 30 {
+		t.Error("the cached quotes shouldn't change")
+	}
+}
@@ -0,0 +1,106 @@
+package currencies
+
+import (
+	"context"
+	"cryptoswap/internal/services/models"
+	"sync"
+
+	"github.com/samber/lo"
+)
+
+// bridgeAssets are the liquid assets tried as the middle of a two-leg route.
+var bridgeAssets = []models.NetworkPair{
+	models.NewNetworkPair("btc", "btc"),
+	models.NewNetworkPair("eth", "eth"),
+	models.NewNetworkPair("usdt", "eth"),
+	models.NewNetworkPair("usdt", "trx"),
+}
+
+// getRoutes looks for two-leg routes through the bridge assets that some
+// exchange lists on both sides of each leg, best first.
+func (cs *currencyService) getRoutes(ctx context.Context, request models.QuoteRequest) []models.Route {
+	bridges := lo.Filter(bridgeAssets, func(bridge models.NetworkPair, _ int) bool {
+		return bridge != request.From && bridge != request.To
+	})
+
+	currencies, err := cs.db.GetCurrenciesByPairs(ctx, append([]models.NetworkPair{request.From, request.To},
+		bridges...)...)
+	if err != nil {
+		cs.logger.Errorf(ctx, "Error getting bridge currencies: %+v", err)
+		return []models.Route{}
+	}
+	lookup := toNetworkLookup(currencies)
+
+	routes := []models.Route{}
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, bridge := range bridges {
+		if len(cs.getPairExchanges(request.From, bridge, lookup)) == 0 ||
+			len(cs.getPairExchanges(bridge, request.To, lookup)) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			route, ok := cs.getRoute(ctx, request, bridge, lookup)
+			if !ok {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			routes = append(routes, route)
+		}()
+	}
+	wg.Wait()
+
+	models.SortRoutes(routes, request.AmountType)
+	return routes
+}
+
+// getRoute quotes both legs through the bridge, each on its best exchange. The
+// fixed side is quoted first: the amount sent, or received on reverse requests.
+func (cs *currencyService) getRoute(ctx context.Context, request models.QuoteRequest, bridge models.NetworkPair,
+	lookup map[models.NetworkPair]models.Currency) (models.Route, bool) {
+	firstRequest := models.NewQuoteRequest(request.From, bridge, request.Amount, request.Rate).
+		WithAmountType(request.AmountType)
+	lastRequest := models.NewQuoteRequest(bridge, request.To, request.Amount, request.Rate).
+		WithAmountType(request.AmountType)
+
+	var first, last models.Quote
+	var ok bool
+	if request.AmountType.IsReverse() {
+		if last, ok = cs.getBestLeg(ctx, lastRequest, lookup); ok {
+			firstRequest.Amount = last.FromAmount
+			first, ok = cs.getBestLeg(ctx, firstRequest, lookup)
+		}
+	} else {
+		if first, ok = cs.getBestLeg(ctx, firstRequest, lookup); ok {
+			lastRequest.Amount = first.Amount
+			last, ok = cs.getBestLeg(ctx, lastRequest, lookup)
+		}
+	}
+	if !ok {
+		return models.Route{}, false
+	}
+
+	return models.NewRoute(bridge, []models.Quote{first, last}, lookup), true
+}
+
+// getBestLeg returns the best quote within limits for a route leg. The legs
+// weren't asked for, they stay out of the quote history.
+func (cs *currencyService) getBestLeg(ctx context.Context, request models.QuoteRequest,
+	lookup map[models.NetworkPair]models.Currency) (models.Quote, bool) {
+	result, err := cs.getQuotesFromAllExchanges(withoutQuoteRecords(ctx), request, lookup)
+	if err != nil {
+		cs.logger.Infof(ctx, "No %s to %s leg: %v", request.From, request.To, err)
+		return models.Quote{}, false
+	}
+
+	quotes := result.Rank(models.RankPayout, cs.getWeight).Quotes
+	if len(quotes) == 0 || !quotes[0].WithinLimits {
+		return models.Quote{}, false
+	}
+	return quotes[0], true
+}
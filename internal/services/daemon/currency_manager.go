@@ -8,12 +8,17 @@ import (
 	"cryptoswap/internal/services/models"
 	"sync"
 	"time"
+
+	"github.com/samber/lo"
 )
 
 const (
 	targetCurrency = "usd"
 	defaultResults = 250
 	pages          = 100
+	// priceHistoryRetention is how long the snapshots and minute candles are
+	// kept, the hourly and daily candles are kept for good.
+	priceHistoryRetention = 7 * 24 * time.Hour
 )
 
 func NewCurrencyManager(logger logger.Logger, repository interfaces.CurrencyRepository,
@@ -62,6 +67,8 @@ func (cm *currencyManager) storeCurrencies(ctx context.Context) {
 	}
 
 	currencies := &[]models.Currency{}
+	failed := []string{}
+	mu := &sync.Mutex{}
 	wg := &sync.WaitGroup{}
 	for _, currencyFetcher := range cm.currencyFetchers {
 		wg.Add(1)
@@ -69,9 +76,17 @@ func (cm *currencyManager) storeCurrencies(ctx context.Context) {
 			defer wg.Done()
 			currs, err := currencyFetcher.GetCurrencies(ctx)
 			if err != nil {
-				cm.logger.Errorf(ctx, "Error fetching currencies: %v", err)
+				cm.logger.Errorf(ctx, "Error fetching currencies from %s: %v",
+					currencyFetcher.GetExchangeName(), err)
+				mu.Lock()
+				defer mu.Unlock()
+				failed = append(failed, currencyFetcher.GetExchangeName())
 				return
 			}
+
+			// Each currency carries the exchange listing it, stored per network
+			mu.Lock()
+			defer mu.Unlock()
 			*currencies = append(*currencies, currs...)
 		}(wg, currencies)
 	}
@@ -79,8 +94,19 @@ func (cm *currencyManager) storeCurrencies(ctx context.Context) {
 	wg.Wait()
 	cm.logger.Infof(ctx, "Fetched %d currencies", len(*currencies))
 
-	allCurrencies := append(existingCurrencies, *currencies...)
-	manager := models.NewCurrencies(allCurrencies...)
+	// The listings fetched replace the stored ones, the exchanges that couldn't
+	// be fetched keep their stored listings until the next cycle
+	carried := lo.FilterMap(existingCurrencies, func(currency models.Currency, _ int) (models.Currency, bool) {
+		currency = currency.WithOnlyExchanges(failed)
+		return currency, len(currency.GetNetworks()) > 0
+	})
+	// Tokens keep the symbols they are stored with, so that no symbol moves to
+	// another contract between refreshes
+	manager := models.NewCurrencies(existingCurrencies, append(*currencies, carried...)...)
+	if conflicts := manager.GetConflicts(); len(conflicts) > 0 {
+		cm.logger.Warningf(ctx, "Skipped %d listings without contract of tickers shared by several tokens: %v",
+			len(conflicts), conflicts)
+	}
 	if err := cm.repository.InsertCurrencies(ctx, manager.GetCurrencies()); err != nil {
 		cm.logger.Errorf(ctx, "Error inserting currencies: %v", err)
 	}
@@ -94,7 +120,8 @@ func (cm *currencyManager) updatePrices(ctx context.Context) {
 		return
 	}
 
-	manager := models.NewCurrencies(currencies...)
+	manager := models.NewCurrencies(nil, currencies...)
+	takenAt := time.Now()
 	for page := range pages {
 		if !manager.HasMorePricesToUpdate() {
 			break
@@ -112,7 +139,7 @@ func (cm *currencyManager) updatePrices(ctx context.Context) {
 		}
 
 		for _, ticker := range pageTickers {
-			manager.UpdatePrice(ticker.Symbol, ticker.Price)
+			manager.UpdatePrice(ticker.Symbol, ticker.Price, ticker.Change)
 		}
 
 		updatedPrices := manager.ExtractPricesToUpdate()
@@ -120,6 +147,15 @@ func (cm *currencyManager) updatePrices(ctx context.Context) {
 			cm.logger.Errorf(ctx, "Error updating prices: %v", err)
 		}
 
+		snapshots := models.NewPriceSnapshots(updatedPrices, takenAt)
+		if err := cm.repository.InsertPriceSnapshots(ctx, snapshots); err != nil {
+			cm.logger.Errorf(ctx, "Error inserting price snapshots: %v", err)
+		}
+
 		time.Sleep(time.Second * 10)
 	}
+
+	if err := cm.repository.DeletePriceHistoryBefore(ctx, takenAt.Add(-priceHistoryRetention)); err != nil {
+		cm.logger.Errorf(ctx, "Error deleting old price history: %v", err)
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"cryptoswap/internal/lib/apierrors"
 	"cryptoswap/internal/services/models"
+	"time"
 )
 
 type CashFetcher interface {
@@ -13,23 +14,73 @@ type CashFetcher interface {
 type CurrencyFetcher interface {
 	GetExchangeName() string
 	GetCurrencies(ctx context.Context) ([]models.Currency, *apierrors.ApiError)
-	GetQuote(ctx context.Context, from, to models.NetworkPair, amount float64) (models.Quote, *apierrors.ApiError)
+	GetQuote(ctx context.Context, request models.QuoteRequest) (models.Quote, *apierrors.ApiError)
+}
+
+// SwapCreator is implemented by the exchanges that can open a swap on their
+// side and hand back the deposit address the user has to pay to.
+type SwapCreator interface {
+	GetExchangeName() string
+	CreateSwap(ctx context.Context, swap models.Swap) (models.ExchangeSwap, *apierrors.ApiError)
+}
+
+// SwapStatusFetcher is implemented by the exchanges that can report the
+// progress of a swap created on their side.
+type SwapStatusFetcher interface {
+	GetExchangeName() string
+	GetSwapStatus(ctx context.Context, exchangeId string) (models.ExchangeSwapStatus, *apierrors.ApiError)
+}
+
+// LimitFetcher is implemented by the exchanges that expose the amount range
+// they accept for a pair.
+type LimitFetcher interface {
+	GetExchangeName() string
+	GetLimits(ctx context.Context, from, to models.NetworkPair, rate models.RateMode) (models.Limits, *apierrors.ApiError)
+}
+
+// QuoteLimiter is implemented by the exchanges whose quotes already carry the
+// limits of the pair, so the quotes fan-out doesn't fetch them apart.
+type QuoteLimiter interface {
+	LimitFetcher
+	HasQuoteLimits() bool
 }
 
 type CurrencyRepository interface {
 	GetCurrencies(ctx context.Context, filters models.Filters) ([]models.Currency, *apierrors.ApiError)
+	GetCurrency(ctx context.Context, symbol string) (models.Currency, *apierrors.ApiError)
 	GetCurrenciesByPairs(ctx context.Context, pairs ...models.NetworkPair) ([]models.Currency, *apierrors.ApiError)
 	InsertCurrencies(ctx context.Context, currencies []models.Currency) *apierrors.ApiError
 	UpdatePrices(ctx context.Context, currencies []models.Currency) *apierrors.ApiError
 	SwapRepository
+	QuoteHistoryRepository
+	PriceHistoryRepository
 }
 
 type SwapRepository interface {
 	GetSwap(ctx context.Context, id string) (models.Swap, *apierrors.ApiError)
 	InsertSwap(ctx context.Context, swap models.Swap) (models.Swap, *apierrors.ApiError)
 	UpdateSwap(ctx context.Context, swap models.Swap) *apierrors.ApiError
+	GetChildSwaps(ctx context.Context, parentId string) ([]models.Swap, *apierrors.ApiError)
+	InsertSplitSwap(ctx context.Context, swap models.Swap) (models.Swap, *apierrors.ApiError)
+}
+
+// QuoteHistoryRepository keeps how the exchanges answered the quotes.
+type QuoteHistoryRepository interface {
+	InsertQuoteRecords(ctx context.Context, records []models.QuoteRecord) *apierrors.ApiError
+	GetExchangeStats(ctx context.Context, from, to models.NetworkPair,
+		since time.Time) ([]models.ExchangeStats, *apierrors.ApiError)
+}
+
+// PriceHistoryRepository keeps the price snapshots and their candles.
+type PriceHistoryRepository interface {
+	InsertPriceSnapshots(ctx context.Context, snapshots []models.PriceSnapshot) *apierrors.ApiError
+	DeletePriceHistoryBefore(ctx context.Context, before time.Time) *apierrors.ApiError
+	GetLatestPriceSnapshot(ctx context.Context, symbol string) (models.PriceSnapshot, *apierrors.ApiError)
+	GetCandles(ctx context.Context, symbol string, interval models.CandleInterval,
+		limit int) ([]models.Candle, *apierrors.ApiError)
 }
 
 type SwapNotifier interface {
 	NotifySwap(ctx context.Context, swap models.Swap) *apierrors.ApiError
+	NotifySwapLater(ctx context.Context, swap models.Swap, delay time.Duration) *apierrors.ApiError
 }
@@ -1,34 +1,191 @@
 package models
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/samber/lo"
 )
 
-func NewCurrencies(currencies ...Currency) Currencies {
-	currencyLookup := make(map[string]Currency)
+// NewCurrencies merges the exchange listings into assets. A token is
+// identified by its contract on the network, so tokens sharing a ticker are
+// kept as separate assets: a token keeps the symbol it is known with, and the
+// first of the new ones by contract takes the ticker when no token holds it,
+// the others get it suffixed with their contract. Listings without contract
+// join the asset of their ticker on the network, unless several tokens share
+// it and there is no telling which one the exchange lists.
+func NewCurrencies(known []Currency, currencies ...Currency) Currencies {
+	tokens := newAssetLookup[string]()
+	coins := newAssetLookup[NetworkPair]()
 	for _, currency := range currencies {
-		symbol := currency.GetLowerSymbol()
-		network := currency.GetFirstNetwork().Network
+		for _, pair := range currency.GetNetworks() {
+			listing := assetListing{currency: currency, info: currency.GetNetworkInfo(pair)}
+			if listing.info.Contract == "" {
+				coins.add(pair, pair, listing)
+				continue
+			}
+			tokens.add(tokenKey(pair.Network, listing.info.Contract), pair, listing)
+		}
+	}
+
+	knownSymbols := map[string]string{}
+	for _, currency := range known {
+		for _, pair := range currency.GetNetworks() {
+			if contract := currency.GetNetworkInfo(pair).Contract; contract != "" {
+				knownSymbols[tokenKey(pair.Network, contract)] = pair.Symbol
+			}
+		}
+	}
 
-		if lookupCurr, ok := currencyLookup[symbol]; ok {
-			currencyLookup[symbol] = lookupCurr.WithNetworks(network).
-				WithAddressValidation(currency.AddressValidation)
-			continue
+	// Tokens sharing a ticker on a network are told apart by their contract
+	byTicker := map[NetworkPair][]*asset{}
+	for _, token := range tokens.assets {
+		pair := newPair(token.getTicker(), token.network)
+		byTicker[pair] = append(byTicker[pair], token)
+	}
+	for pair, shared := range byTicker {
+		slices.SortStableFunc(shared, func(a, b *asset) int {
+			return strings.Compare(strings.ToLower(a.contract), strings.ToLower(b.contract))
+		})
+		tickerTaken := false
+		for _, token := range shared {
+			symbol := knownSymbols[tokenKey(token.network, token.contract)]
+			if symbol == pair.Symbol || symbol == GetTokenSymbol(pair.Symbol, token.contract) {
+				token.symbol = symbol
+				tickerTaken = tickerTaken || symbol == pair.Symbol
+			}
+		}
+		for _, token := range shared {
+			if token.symbol != "" {
+				continue
+			}
+			if tickerTaken {
+				token.symbol = GetTokenSymbol(pair.Symbol, token.contract)
+				continue
+			}
+			token.symbol, tickerTaken = pair.Symbol, true
+		}
+	}
+
+	assets := tokens.assets
+	conflicts := []NetworkPair{}
+	for _, coin := range coins.assets {
+		pair := newPair(coin.getTicker(), coin.network)
+		switch shared := byTicker[pair]; len(shared) {
+		case 0:
+			coin.symbol = pair.Symbol
+			assets = append(assets, coin)
+		case 1:
+			shared[0].listings = append(shared[0].listings, coin.listings...)
+		default:
+			conflicts = append(conflicts, pair)
 		}
-		currencyLookup[symbol] = currency
 	}
 
 	return Currencies{
-		currencies:    currencyLookup,
+		currencies:    mergeAssets(assets),
 		updatedPrices: make(map[string]Currency),
+		conflicts:     conflicts,
 	}
 }
 
+// GetTokenSymbol returns the symbol of a token sharing its ticker with another
+// token on the network, suffixed with the end of its contract.
+func GetTokenSymbol(ticker, contract string) string {
+	contract = strings.ToLower(contract)
+	return ticker + "-" + contract[max(len(contract)-tokenSuffixLength, 0):]
+}
+
+const tokenSuffixLength = 6
+
+func tokenKey(network, contract string) string {
+	return network + ":" + strings.ToLower(contract)
+}
+
+// asset is one coin or token on a network, along with every listing of it.
+type asset struct {
+	network  string
+	contract string
+	symbol   string
+	listings []assetListing
+}
+
+type assetListing struct {
+	currency Currency
+	ticker   string
+	info     NetworkInfo
+}
+
+// getTicker returns the ticker most exchanges list the asset with.
+func (a *asset) getTicker() string {
+	votes := map[string]int{}
+	for _, listing := range a.listings {
+		votes[listing.ticker] += max(len(listing.info.Exchanges), 1)
+	}
+	tickers := lo.Keys(votes)
+	slices.Sort(tickers)
+	return lo.MaxBy(tickers, func(a, b string) bool {
+		return votes[a] > votes[b]
+	})
+}
+
+// assetLookup keeps the assets in the order they were first listed.
+type assetLookup[K comparable] struct {
+	lookup map[K]*asset
+	assets []*asset
+}
+
+func newAssetLookup[K comparable]() assetLookup[K] {
+	return assetLookup[K]{lookup: map[K]*asset{}}
+}
+
+func (l *assetLookup[K]) add(key K, pair NetworkPair, listing assetListing) {
+	listing.ticker = pair.Symbol
+	found, ok := l.lookup[key]
+	if !ok {
+		found = &asset{network: pair.Network, contract: listing.info.Contract}
+		l.lookup[key] = found
+		l.assets = append(l.assets, found)
+	}
+	found.listings = append(found.listings, listing)
+}
+
+// mergeAssets groups the assets by symbol, the first listing of a symbol sets
+// its name and image. Every exchange keeps the ticker it lists the asset with.
+func mergeAssets(assets []*asset) map[string]Currency {
+	currencyLookup := make(map[string]Currency)
+	for _, asset := range assets {
+		for _, listing := range asset.listings {
+			for _, exchange := range listing.info.Exchanges {
+				if _, ok := listing.info.Tickers[exchange]; !ok {
+					listing.info = listing.info.WithTicker(exchange, listing.ticker)
+				}
+			}
+			currency, ok := currencyLookup[asset.symbol]
+			if !ok {
+				currency = listing.currency
+				currency.Symbol = asset.symbol
+				currency.Networks = newNetworks()
+			}
+			currencyLookup[asset.symbol] = currency.
+				WithNetwork(asset.network, listing.info).
+				WithAddressValidation(listing.currency.AddressValidation)
+		}
+	}
+	return currencyLookup
+}
+
 type Currencies struct {
 	currencies    map[string]Currency
 	updatedPrices map[string]Currency
+	conflicts     []NetworkPair
+}
+
+// GetConflicts returns the networks left out of the merge because several
+// tokens share the ticker on them and the listing has no contract to tell
+// which one it is.
+func (c *Currencies) GetConflicts() []NetworkPair {
+	return c.conflicts
 }
 
 func (c *Currencies) GetCurrencies() []Currency {
@@ -53,13 +210,13 @@ func (c Currencies) Has(symbol string) bool {
 	return ok
 }
 
-func (c Currencies) UpdatePrice(symbol string, price float64) bool {
+func (c Currencies) UpdatePrice(symbol string, price, change float64) bool {
 	symbol = strings.ToLower(symbol)
 	if !c.Has(symbol) {
 		return false
 	}
 
-	c.updatedPrices[symbol] = c.currencies[symbol].WithPrice(price)
+	c.updatedPrices[symbol] = c.currencies[symbol].WithPrice(price).WithChange(change)
 	delete(c.currencies, symbol)
 	return true
 }
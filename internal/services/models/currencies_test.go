@@ -0,0 +1,112 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func newListing(exchange, symbol, network, contract string) Currency {
+	return NewCurrency(exchange, network, symbol, symbol, "", "", true).
+		WithNetworkInfo(NetworkInfo{Contract: contract})
+}
+
+func TestNewCurrencies_SameContract(t *testing.T) {
+	manager := NewCurrencies(nil,
+		newListing("A", "usdt", "eth", "0xdAC17F958D2ee523a2206206994597C13D831ec7"),
+		newListing("B", "usdt", "eth", "0xdac17f958d2ee523a2206206994597c13d831ec7"),
+		newListing("C", "usdt", "eth", ""),
+		newListing("C", "usdt", "trx", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"),
+	)
+
+	currencies := manager.GetCurrencies()
+	if len(currencies) != 1 {
+		t.Fatalf("expected a single currency, got %+v", currencies)
+	}
+	eth := currencies[0].GetNetworkInfo(NewNetworkPair("usdt", "eth"))
+	if !slices.Equal(eth.Exchanges, []string{"A", "B", "C"}) {
+		t.Errorf("expected every exchange on the token, got %v", eth.Exchanges)
+	}
+	if len(currencies[0].GetNetworks()) != 2 || len(manager.GetConflicts()) != 0 {
+		t.Errorf("unexpected merge: %v, conflicts %v", currencies[0].GetNetworks(), manager.GetConflicts())
+	}
+}
+
+func TestNewCurrencies_SharedTicker(t *testing.T) {
+	tether := "0xdac17f958d2ee523a2206206994597c13d831ec7"
+	impostor := "0x1234567890abcdef1234567890abcdef12345678"
+	stored := NewCurrencies(nil, newListing("A", "usdt", "eth", tether))
+
+	tests := []struct {
+		name     string
+		known    []Currency
+		ticker   string
+		suffixed string
+	}{
+		{name: "new tokens", ticker: impostor, suffixed: tether},
+		{name: "known token", known: stored.GetCurrencies(), ticker: tether, suffixed: impostor},
+	}
+	for _, tt := range tests {
+		// Neither the listing order nor how many exchanges list a token matter
+		for _, impostorFirst := range []bool{true, false} {
+			listings := []Currency{newListing("A", "usdt", "eth", tether)}
+			impostorListings := []Currency{
+				newListing("B", "usdt", "eth", impostor),
+				newListing("C", "usdt", "eth", impostor),
+			}
+			if impostorFirst {
+				listings = append(impostorListings, listings...)
+			} else {
+				listings = append(listings, impostorListings...)
+			}
+
+			manager := NewCurrencies(tt.known, listings...)
+			symbol := GetTokenSymbol("usdt", tt.suffixed)
+			if !manager.Has("usdt") || !manager.Has(symbol) {
+				t.Fatalf("%s: expected both tokens, got %+v", tt.name, manager.GetCurrencies())
+			}
+			usdt := manager.currencies["usdt"]
+			if info := usdt.GetNetworkInfo(NewNetworkPair("usdt", "eth")); info.Contract != tt.ticker {
+				t.Errorf("%s: expected usdt to be %s, got %s", tt.name, tt.ticker, info.Contract)
+			}
+			separate := manager.currencies[symbol]
+			info := separate.GetNetworkInfo(NewNetworkPair(symbol, "eth"))
+			if info.Contract != tt.suffixed {
+				t.Errorf("%s: unexpected separate token: %+v", tt.name, info)
+			}
+			for _, exchange := range info.Exchanges {
+				if ticker := info.GetTicker(exchange, symbol); ticker != "usdt" {
+					t.Errorf("%s: expected %s to keep its ticker, got %s", tt.name, exchange, ticker)
+				}
+			}
+		}
+	}
+}
+
+func TestNewCurrencies_AmbiguousListing(t *testing.T) {
+	manager := NewCurrencies(nil,
+		newListing("A", "usdt", "eth", "0xdac17f958d2ee523a2206206994597c13d831ec7"),
+		newListing("B", "usdt", "eth", "0x1234567890abcdef1234567890abcdef12345678"),
+		newListing("C", "usdt", "eth", ""),
+	)
+
+	conflicts := manager.GetConflicts()
+	if len(conflicts) != 1 || conflicts[0] != NewNetworkPair("usdt", "eth") {
+		t.Fatalf("expected the listing without contract to conflict, got %v", conflicts)
+	}
+	for _, currency := range manager.GetCurrencies() {
+		for _, pair := range currency.GetNetworks() {
+			if currency.IsSupportedBy(pair, "C") {
+				t.Errorf("expected C to be left out, got it on %s", pair)
+			}
+		}
+	}
+}
+
+func TestGetTokenSymbol(t *testing.T) {
+	if symbol := GetTokenSymbol("usdt", "0x1234567890ABCDEF1234567890abcdef12345678"); symbol != "usdt-345678" {
+		t.Errorf("unexpected symbol %s", symbol)
+	}
+	if symbol := GetTokenSymbol("abc", "xyz"); symbol != "abc-xyz" {
+		t.Errorf("unexpected symbol %s", symbol)
+	}
+}
@@ -45,7 +45,7 @@ func NewCurrency(provider, network, symbol, name, addressValidation, image strin
 		Image:             image,
 		Available:         available,
 		AddressValidation: addressValidation,
-		Networks:          *nw.Add(symbol, network),
+		Networks:          *nw.AddWithInfo(symbol, network, NetworkInfo{Exchanges: []string{provider}}),
 		provider:          provider,
 	}
 }
@@ -57,6 +57,7 @@ type Currency struct {
 	Available         bool     `json:"available"`
 	AddressValidation string   `json:"addressValidation,omitempty"`
 	Price             float64  `json:"price,omitempty"`
+	Change24h         float64  `json:"change24h,omitempty"`
 	Networks          Networks `json:"networks,omitempty"`
 	provider          string
 }
@@ -85,11 +86,44 @@ func (c Currency) WithNetworks(networks ...string) Currency {
 	return c
 }
 
+// WithNetworkInfo sets the info of the network the currency was created with.
+func (c Currency) WithNetworkInfo(info NetworkInfo) Currency {
+	first := c.GetFirstNetwork()
+	c.Networks.AddWithInfo(first.Symbol, first.Network, info)
+	return c
+}
+
+// WithNetwork adds a network along with its info.
+func (c Currency) WithNetwork(network string, info NetworkInfo) Currency {
+	c.Networks.AddWithInfo(c.Symbol, network, info)
+	return c
+}
+
+// WithOnlyExchanges keeps the networks listed by the given exchanges, and only
+// their listings on them.
+func (c Currency) WithOnlyExchanges(exchanges []string) Currency {
+	networks := newNetworks()
+	for _, pair := range c.GetNetworks() {
+		info := c.GetNetworkInfo(pair).WithOnlyExchanges(exchanges)
+		if len(info.Exchanges) > 0 {
+			networks.AddWithInfo(pair.Symbol, pair.Network, info)
+		}
+	}
+	c.Networks = networks
+	return c
+}
+
 func (c Currency) WithPrice(price float64) Currency {
 	c.Price = price
 	return c
 }
 
+// WithChange sets the price change over the previous day, in percent.
+func (c Currency) WithChange(change float64) Currency {
+	c.Change24h = change
+	return c
+}
+
 func (c Currency) WithProvider(provider string) Currency {
 	c.provider = provider
 	return c
@@ -99,6 +133,21 @@ func (c *Currency) GetNetworks() []NetworkPair {
 	return c.Networks.GetAll()
 }
 
+func (c *Currency) GetNetworkInfo(pair NetworkPair) NetworkInfo {
+	return c.Networks.GetInfo(pair)
+}
+
+// IsSupportedBy tells if the exchange lists the currency on the network.
+func (c *Currency) IsSupportedBy(pair NetworkPair, exchange string) bool {
+	return c.GetNetworkInfo(pair).IsSupportedBy(exchange)
+}
+
+// GetExchangePair returns the pair as the exchange lists it, a token renamed
+// to tell it apart goes by the exchange ticker.
+func (c *Currency) GetExchangePair(pair NetworkPair, exchange string) NetworkPair {
+	return newPair(c.GetNetworkInfo(pair).GetTicker(exchange, pair.Symbol), pair.Network)
+}
+
 func (c *Currency) GetLowerSymbol() string {
 	return strings.ToLower(c.Symbol)
 }
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCurrency_WithOnlyExchanges(t *testing.T) {
+	eth := NewNetworkPair("usdt", "eth")
+	trx := NewNetworkPair("usdt", "trx")
+	currency := NewCurrency("A", "eth", "usdt", "Tether", "", "", true).
+		WithNetwork("eth", NetworkInfo{Exchanges: []string{"B"}}.WithWarnings("B", Warnings{From: []string{"ERC20"}})).
+		WithNetwork("trx", NetworkInfo{Exchanges: []string{"A"}})
+
+	kept := currency.WithOnlyExchanges([]string{"B"})
+	if networks := kept.GetNetworks(); len(networks) != 1 || networks[0] != eth {
+		t.Fatalf("expected only the network listed by B, got %v", networks)
+	}
+	info := kept.GetNetworkInfo(eth)
+	if !slices.Equal(info.Exchanges, []string{"B"}) || len(info.GetWarnings("B").From) != 1 {
+		t.Errorf("unexpected info: %+v", info)
+	}
+
+	// The original currency is left as it was
+	if !currency.IsSupportedBy(eth, "A") || !currency.IsSupportedBy(trx, "A") {
+		t.Errorf("expected the currency to be untouched, got %+v", currency.Networks)
+	}
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+type ExchangeStatus string
+
+const (
+	ExchangeStatusOk          ExchangeStatus = "ok"
+	ExchangeStatusError       ExchangeStatus = "error"
+	ExchangeStatusTimeout     ExchangeStatus = "timeout"
+	ExchangeStatusUnsupported ExchangeStatus = "unsupported"
+)
+
+// Error categories tell apart why an exchange didn't quote.
+const (
+	ErrorCategoryTimeout      = "timeout"
+	ErrorCategoryRateLimited  = "rate_limited"
+	ErrorCategoryOutOfLimits  = "out_of_limits"
+	ErrorCategoryCircuitOpen  = "circuit_open"
+	ErrorCategoryBulkheadFull = "bulkhead_full"
+	ErrorCategoryRejected     = "rejected"
+	ErrorCategoryUnavailable  = "unavailable"
+	ErrorCategoryUnknown      = "unknown"
+)
+
+// ExchangeDiagnostic is the outcome of asking one exchange for a quote.
+type ExchangeDiagnostic struct {
+	Exchange      string
+	Status        ExchangeStatus
+	Latency       time.Duration
+	ErrorCategory string
+}
+
+func NewExchangeDiagnostic(exchange string, status ExchangeStatus, latency time.Duration) ExchangeDiagnostic {
+	return ExchangeDiagnostic{
+		Exchange: exchange,
+		Status:   status,
+		Latency:  latency,
+	}
+}
+
+func (d ExchangeDiagnostic) WithErrorCategory(category string) ExchangeDiagnostic {
+	d.ErrorCategory = category
+	return d
+}
+
+// QuoteResult holds the quotes found and how every exchange answered. Routes
+// are only looked for when no exchange quotes the pair directly.
+type QuoteResult struct {
+	Quotes    []Quote
+	Routes    []Route
+	Exchanges []ExchangeDiagnostic
+}
+
+func NewQuoteResult(quotes []Quote, diagnostics map[string]ExchangeDiagnostic) QuoteResult {
+	exchanges := make([]ExchangeDiagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		exchanges = append(exchanges, diagnostic)
+	}
+	sort.Slice(exchanges, func(i, j int) bool {
+		return exchanges[i].Exchange < exchanges[j].Exchange
+	})
+
+	return QuoteResult{
+		Quotes:    quotes,
+		Exchanges: exchanges,
+	}
+}
+
+func (r QuoteResult) WithRoutes(routes []Route) QuoteResult {
+	r.Routes = routes
+	return r
+}
+
+func (r QuoteResult) IsEmpty() bool {
+	return len(r.Quotes) == 0 && len(r.Routes) == 0
+}
+
+// ScaledTo returns the result for another requested amount at the same rates.
+func (r QuoteResult) ScaledTo(requested, amount float64) QuoteResult {
+	if requested == amount {
+		return r
+	}
+
+	quotes := make([]Quote, len(r.Quotes))
+	for i, quote := range r.Quotes {
+		quotes[i] = quote.ScaledTo(requested, amount)
+	}
+	routes := make([]Route, len(r.Routes))
+	for i, route := range r.Routes {
+		routes[i] = route.ScaledTo(requested, amount)
+	}
+	r.Quotes, r.Routes = quotes, routes
+	return r
+}
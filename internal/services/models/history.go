@@ -0,0 +1,105 @@
+package models
+
+import (
+	"cryptoswap/internal/lib/ids"
+	"time"
+)
+
+// QuoteRecord is how an exchange answered one quote fan-out, kept to compare
+// the exchanges of a pair over time.
+type QuoteRecord struct {
+	FanOutId      string
+	Exchange      string
+	From          NetworkPair
+	To            NetworkPair
+	RateMode      RateMode
+	AmountType    AmountType
+	FromAmount    float64
+	Amount        float64
+	Difference    *float64
+	Latency       time.Duration
+	Status        ExchangeStatus
+	ErrorCategory string
+	// Won tells if the exchange gave the best payout of the fan-out.
+	Won bool
+}
+
+// NewQuoteRecords records every exchange that was asked for the quote, the
+// ones not listing the pair are left out.
+func NewQuoteRecords(request QuoteRequest, result QuoteResult) []QuoteRecord {
+	fanOutId := ids.NewQuoteFanOutId()
+	quotes := map[string]Quote{}
+	for _, quote := range result.Quotes {
+		quotes[quote.Exchange] = quote
+	}
+	// The winner is the raw best payout, regardless of the ranking weights
+	winner := ""
+	ranked := result.Rank(RankPayout, func(string) float64 { return 1 }).Quotes
+	if len(ranked) > 0 && ranked[0].WithinLimits {
+		winner = ranked[0].Exchange
+	}
+
+	records := []QuoteRecord{}
+	for _, diagnostic := range result.Exchanges {
+		if diagnostic.Status == ExchangeStatusUnsupported {
+			continue
+		}
+		quote := quotes[diagnostic.Exchange]
+		records = append(records, QuoteRecord{
+			FanOutId:      fanOutId,
+			Exchange:      diagnostic.Exchange,
+			From:          request.From,
+			To:            request.To,
+			RateMode:      request.Rate,
+			AmountType:    request.AmountType,
+			FromAmount:    quote.FromAmount,
+			Amount:        quote.Amount,
+			Difference:    quote.getDifference(),
+			Latency:       diagnostic.Latency,
+			Status:        diagnostic.Status,
+			ErrorCategory: diagnostic.ErrorCategory,
+			Won:           diagnostic.Exchange == winner,
+		})
+	}
+	return records
+}
+
+// getDifference returns the difference of the answered quotes of priced
+// currencies, nil for the others.
+func (q Quote) getDifference() *float64 {
+	if q.IsEmpty() || q.Unpriced {
+		return nil
+	}
+	return &q.Difference
+}
+
+// ExchangeStats sums up how an exchange answered the quotes of a pair.
+type ExchangeStats struct {
+	Exchange string
+	// Requests is how many fan-outs asked the exchange, Quotes how many it
+	// answered and Wins how many it gave the best payout of.
+	Requests int
+	Quotes   int
+	Wins     int
+	// AvgDifference is the mean of the quotes rate against the market, in
+	// percent.
+	AvgDifference float64
+	AvgLatency    time.Duration
+	MaxLatency    time.Duration
+}
+
+// GetWinRate returns the share of the fan-outs the exchange won.
+func (s ExchangeStats) GetWinRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Requests)
+}
+
+// PairAnalytics are the stats of every exchange quoting a pair since a time.
+type PairAnalytics struct {
+	From      NetworkPair
+	To        NetworkPair
+	Since     time.Time
+	Exchanges []ExchangeStats
+}
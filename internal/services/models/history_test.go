@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewQuoteRecords(t *testing.T) {
+	request := NewQuoteRequest(NewNetworkPair("btc", "btc"), NewNetworkPair("eth", "eth"), 1, RateFloating)
+	result := QuoteResult{
+		Quotes: []Quote{
+			{Exchange: "A", FromAmount: 1, Amount: 30, Difference: -1, WithinLimits: true},
+			{Exchange: "B", FromAmount: 1, Amount: 31, Difference: 2, WithinLimits: true},
+			{Exchange: "C", FromAmount: 1, Amount: 40, Unpriced: true, WithinLimits: false},
+		},
+		Exchanges: []ExchangeDiagnostic{
+			NewExchangeDiagnostic("A", ExchangeStatusOk, 100*time.Millisecond),
+			NewExchangeDiagnostic("B", ExchangeStatusOk, 200*time.Millisecond),
+			NewExchangeDiagnostic("C", ExchangeStatusOk, 300*time.Millisecond),
+			NewExchangeDiagnostic("D", ExchangeStatusTimeout, time.Second).WithErrorCategory(ErrorCategoryTimeout),
+			NewExchangeDiagnostic("E", ExchangeStatusUnsupported, 0),
+		},
+	}
+
+	records := NewQuoteRecords(request, result)
+	if len(records) != 4 {
+		t.Fatalf("expected 4 records, got %+v", records)
+	}
+
+	byExchange := map[string]QuoteRecord{}
+	for _, record := range records {
+		if record.FanOutId != records[0].FanOutId {
+			t.Error("expected the records to share the fan-out id")
+		}
+		byExchange[record.Exchange] = record
+	}
+	if winner := byExchange["B"]; !winner.Won || winner.Amount != 31 || *winner.Difference != 2 {
+		t.Errorf("expected B to win, got %+v", winner)
+	}
+	if byExchange["A"].Won || byExchange["C"].Won {
+		t.Error("expected a single winner within limits")
+	}
+	if byExchange["C"].Difference != nil {
+		t.Errorf("expected no difference for the unpriced quote, got %f", *byExchange["C"].Difference)
+	}
+	if timeout := byExchange["D"]; timeout.Amount != 0 || timeout.Difference != nil ||
+		timeout.ErrorCategory != ErrorCategoryTimeout || timeout.Latency != time.Second {
+		t.Errorf("unexpected timed out record: %+v", timeout)
+	}
+}
+
+func TestExchangeStats_GetWinRate(t *testing.T) {
+	if rate := (ExchangeStats{Requests: 4, Wins: 1}).GetWinRate(); rate != 0.25 {
+		t.Errorf("expected a 0.25 win rate, got %f", rate)
+	}
+	if rate := (ExchangeStats{}).GetWinRate(); rate != 0 {
+		t.Errorf("expected no win rate without requests, got %f", rate)
+	}
+}
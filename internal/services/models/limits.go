@@ -0,0 +1,64 @@
+package models
+
+import (
+	"cryptoswap/internal/lib/apierrors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Limits is the amount range an exchange accepts for a pair. A zero Max
+// means the exchange doesn't cap the amount.
+type Limits struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max,omitempty"`
+}
+
+func (l Limits) IsEmpty() bool {
+	return l.Min == 0 && l.Max == 0
+}
+
+func (l Limits) Contains(amount float64) bool {
+	if amount < l.Min {
+		return false
+	}
+	return l.Max == 0 || amount <= l.Max
+}
+
+func (l Limits) String() string {
+	max := "no max"
+	if l.Max != 0 {
+		max = formatAmount(l.Max)
+	}
+	return fmt.Sprintf("[%s, %s]", formatAmount(l.Min), max)
+}
+
+// Validate returns a bad request stating the allowed bounds when the amount
+// is out of the range.
+func (l Limits) Validate(exchange string, amount float64) *apierrors.ApiError {
+	if l.Contains(amount) {
+		return nil
+	}
+	return apierrors.NewApiError(apierrors.BadRequest,
+		fmt.Errorf("amount %s is outside the allowed range %s for %s",
+			formatAmount(amount), l, exchange))
+}
+
+// NewOutOfLimitsError builds the bad request returned when the amount is out
+// of the range of every exchange.
+func NewOutOfLimitsError(amount float64, limits map[string]Limits) *apierrors.ApiError {
+	ranges := make([]string, 0, len(limits))
+	for exchange, limit := range limits {
+		ranges = append(ranges, fmt.Sprintf("%s %s", exchange, limit))
+	}
+	sort.Strings(ranges)
+
+	return apierrors.NewApiError(apierrors.BadRequest,
+		fmt.Errorf("amount %s is outside the allowed range: %s",
+			formatAmount(amount), strings.Join(ranges, ", ")))
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
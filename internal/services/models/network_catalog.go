@@ -0,0 +1,219 @@
+package models
+
+import (
+	"strings"
+	"sync"
+)
+
+// networkCatalog holds the canonical network codes we expose, with their
+// display names. Exchanges are translated to and from these codes.
+var networkCatalog = map[string]string{
+	"btc":      "Bitcoin",
+	"eth":      "Ethereum",
+	"bsc":      "BNB Smart Chain",
+	"bnb":      "BNB Beacon Chain",
+	"trx":      "Tron",
+	"sol":      "Solana",
+	"matic":    "Polygon",
+	"avaxc":    "Avalanche C-Chain",
+	"arbitrum": "Arbitrum One",
+	"op":       "Optimism",
+	"base":     "Base",
+	"ton":      "TON",
+	"ltc":      "Litecoin",
+	"bch":      "Bitcoin Cash",
+	"doge":     "Dogecoin",
+	"xrp":      "XRP Ledger",
+	"xlm":      "Stellar",
+	"eos":      "EOS",
+	"ada":      "Cardano",
+	"dot":      "Polkadot",
+	"atom":     "Cosmos Hub",
+	"near":     "NEAR",
+	"xmr":      "Monero",
+	"algo":     "Algorand",
+}
+
+// networkAliases are spellings shared by several exchanges for a canonical
+// network. Exchange specific codes go in each adapter's translator.
+var networkAliases = map[string]string{
+	"bitcoin":    "btc",
+	"ethereum":   "eth",
+	"erc20":      "eth",
+	"bep20":      "bsc",
+	"bep2":       "bnb",
+	"tron":       "trx",
+	"trc20":      "trx",
+	"solana":     "sol",
+	"spl":        "sol",
+	"polygon":    "matic",
+	"pol":        "matic",
+	"avaxcchain": "avaxc",
+	"cchain":     "avaxc",
+	"arb":        "arbitrum",
+	"optimism":   "op",
+	"litecoin":   "ltc",
+	"dogecoin":   "doge",
+	"ripple":     "xrp",
+	"stellar":    "xlm",
+	"cardano":    "ada",
+	"polkadot":   "dot",
+	"cosmos":     "atom",
+	"monero":     "xmr",
+	"algorand":   "algo",
+}
+
+// nativeNetworks maps coins whose own chain isn't named after their symbol.
+var nativeNetworks = map[string]string{
+	"avax": "avaxc",
+	"pol":  "matic",
+}
+
+// CanonicalNetwork resolves a network code to our catalog, leaving unknown
+// codes lowercased as they are.
+func CanonicalNetwork(network string) string {
+	network = strings.ToLower(network)
+	if canonical, ok := networkAliases[network]; ok {
+		return canonical
+	}
+	return network
+}
+
+// NativeNetwork returns the canonical network of a coin on its own chain.
+func NativeNetwork(symbol string) string {
+	symbol = strings.ToLower(symbol)
+	if network, ok := nativeNetworks[symbol]; ok {
+		return network
+	}
+	return CanonicalNetwork(symbol)
+}
+
+// GetNetworkName returns the display name of a canonical network, or the code
+// itself when it isn't in the catalog.
+func GetNetworkName(network string) string {
+	if name, ok := networkCatalog[network]; ok {
+		return name
+	}
+	return network
+}
+
+// NewNetworkTranslator builds a translator from the exchange network codes to
+// our canonical ones. Codes missing from aliases fall back to the shared table,
+// and the codes seen in the exchange listings are remembered to translate the
+// canonical networks back.
+func NewNetworkTranslator(aliases map[string]string) NetworkTranslator {
+	toCanonical := make(map[string]string, len(aliases))
+	toProvider := make(map[string]string, len(aliases))
+	for code, canonical := range aliases {
+		toCanonical[strings.ToLower(code)] = canonical
+		toProvider[canonical] = code
+	}
+
+	return NetworkTranslator{
+		toCanonical: toCanonical,
+		toProvider:  toProvider,
+		seen: &seenCodes{
+			byPair:    make(map[NetworkPair]string),
+			byNetwork: make(map[string]string),
+		},
+	}
+}
+
+// NetworkTranslator translates network codes between one exchange and the
+// canonical catalog. Copies share the codes seen.
+type NetworkTranslator struct {
+	toCanonical map[string]string
+	toProvider  map[string]string
+	native      string
+	seen        *seenCodes
+}
+
+// seenCodes are the exchange network codes translated so far, by canonical
+// pair and by canonical network.
+type seenCodes struct {
+	mu        sync.RWMutex
+	byPair    map[NetworkPair]string
+	byNetwork map[string]string
+}
+
+// add keeps the code of a listed pair. The code is also kept for the whole
+// network when it isn't the canonical one, except the native code which names
+// a different network on every coin.
+func (s *seenCodes) add(pair NetworkPair, code string, native bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byPair[pair] = code
+	if !native && code != pair.Network {
+		s.byNetwork[pair.Network] = code
+	}
+}
+
+func (s *seenCodes) get(pair NetworkPair) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if code, ok := s.byPair[pair]; ok {
+		return code, true
+	}
+	code, ok := s.byNetwork[pair.Network]
+	return code, ok
+}
+
+// WithNativeCode sets the code the exchange uses for any coin on its own chain,
+// like StealthEX's "mainnet".
+func (t NetworkTranslator) WithNativeCode(code string) NetworkTranslator {
+	t.native = strings.ToLower(code)
+	return t
+}
+
+// ToCanonical translates the network of a listed coin, remembering the code
+// it was listed with.
+func (t NetworkTranslator) ToCanonical(symbol, network string) string {
+	canonical := t.toCanonicalCode(symbol, network)
+	if t.seen != nil {
+		t.seen.add(NewNetworkPair(symbol, canonical), network, t.isNative(network))
+	}
+	return canonical
+}
+
+func (t NetworkTranslator) isNative(network string) bool {
+	return t.native != "" && strings.ToLower(network) == t.native
+}
+
+func (t NetworkTranslator) toCanonicalCode(symbol, network string) string {
+	if t.isNative(network) {
+		return NativeNetwork(symbol)
+	}
+
+	network = strings.ToLower(network)
+	if canonical, ok := t.toCanonical[network]; ok {
+		return canonical
+	}
+
+	return CanonicalNetwork(network)
+}
+
+// ToProvider returns the code the exchange listed the pair with, or else the
+// best guess from the aliases.
+func (t NetworkTranslator) ToProvider(pair NetworkPair) string {
+	if t.seen != nil {
+		if code, ok := t.seen.get(pair); ok {
+			return code
+		}
+	}
+
+	if t.native != "" && pair.Network == NativeNetwork(pair.Symbol) {
+		return t.native
+	}
+
+	if code, ok := t.toProvider[pair.Network]; ok {
+		return code
+	}
+
+	return pair.Network
+}
+
+// ToProviderPair returns the pair with its network as the exchange names it.
+func (t NetworkTranslator) ToProviderPair(pair NetworkPair) NetworkPair {
+	pair.Network = t.ToProvider(pair)
+	return pair
+}
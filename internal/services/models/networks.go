@@ -1,12 +1,21 @@
 package models
 
 import (
+	"cryptoswap/internal/lib/apierrors"
 	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/samber/lo"
 )
 
+func NewNetworkPair(symbol, network string) NetworkPair {
+	return newPair(symbol, network)
+}
+
 func newPair(symbol, network string) NetworkPair {
 	return NetworkPair{
 		Symbol:  strings.ToLower(symbol),
@@ -23,32 +32,199 @@ func (n NetworkPair) String() string {
 	return fmt.Sprintf("%s-%s", n.Symbol, n.Network)
 }
 
+// NetworkInfo is what the exchanges tell about a currency on one network.
+// Tokens carry the contract address they have on the network's chain.
+type NetworkInfo struct {
+	Contract          string   `json:"contract,omitempty"`
+	HasExtraId        bool     `json:"hasExtraId"`
+	ExtraIdValidation string   `json:"extraIdValidation,omitempty"`
+	AddressExplorer   string   `json:"addressExplorer,omitempty"`
+	TxExplorer        string   `json:"txExplorer,omitempty"`
+	Exchanges         []string `json:"exchanges,omitempty"`
+	// Warnings are kept per exchange listing the network.
+	Warnings map[string]Warnings `json:"warnings,omitempty"`
+	// Tickers are the symbols the exchanges list the currency with, kept for
+	// the tokens renamed to tell them apart.
+	Tickers map[string]string `json:"tickers,omitempty"`
+}
+
+// Warnings are the notices an exchange asks to show before sending (From) or
+// receiving (To) the currency on the network, like "send only via BEP20".
+type Warnings struct {
+	From []string `json:"from,omitempty"`
+	To   []string `json:"to,omitempty"`
+}
+
+func (w Warnings) IsEmpty() bool {
+	return len(w.From) == 0 && len(w.To) == 0
+}
+
+// WithWarnings sets the warnings of an exchange, if it has any.
+func (i NetworkInfo) WithWarnings(exchange string, warnings Warnings) NetworkInfo {
+	if warnings.IsEmpty() {
+		return i
+	}
+
+	i.Warnings = maps.Clone(i.Warnings)
+	if i.Warnings == nil {
+		i.Warnings = map[string]Warnings{}
+	}
+	i.Warnings[exchange] = warnings
+	return i
+}
+
+func (i NetworkInfo) GetWarnings(exchange string) Warnings {
+	return i.Warnings[exchange]
+}
+
+// WithTicker sets the symbol the exchange lists the currency with.
+func (i NetworkInfo) WithTicker(exchange, ticker string) NetworkInfo {
+	i.Tickers = maps.Clone(i.Tickers)
+	if i.Tickers == nil {
+		i.Tickers = map[string]string{}
+	}
+	i.Tickers[exchange] = ticker
+	return i
+}
+
+// GetTicker returns the symbol the exchange lists the currency with, the
+// symbol itself when it isn't known.
+func (i NetworkInfo) GetTicker(exchange, symbol string) string {
+	if ticker, ok := i.Tickers[exchange]; ok && ticker != "" {
+		return ticker
+	}
+	return symbol
+}
+
+// Merge keeps the extra id support of any exchange and the first validation
+// and explorers found, and joins the exchanges listing the network. Warnings
+// and tickers of the merged info replace the known ones of the same exchange.
+func (i NetworkInfo) Merge(other NetworkInfo) NetworkInfo {
+	if i.Contract == "" {
+		i.Contract = other.Contract
+	}
+	i.HasExtraId = i.HasExtraId || other.HasExtraId
+	if i.ExtraIdValidation == "" {
+		i.ExtraIdValidation = other.ExtraIdValidation
+	}
+	if i.AddressExplorer == "" {
+		i.AddressExplorer = other.AddressExplorer
+	}
+	if i.TxExplorer == "" {
+		i.TxExplorer = other.TxExplorer
+	}
+	i.Exchanges = lo.Uniq(append(slices.Clone(i.Exchanges), other.Exchanges...))
+	slices.Sort(i.Exchanges)
+	for exchange, warnings := range other.Warnings {
+		i = i.WithWarnings(exchange, warnings)
+	}
+	for exchange, ticker := range other.Tickers {
+		i = i.WithTicker(exchange, ticker)
+	}
+	return i
+}
+
+// WithOnlyExchanges keeps the listings of the given exchanges, with their
+// warnings and tickers.
+func (i NetworkInfo) WithOnlyExchanges(exchanges []string) NetworkInfo {
+	i.Exchanges = lo.Intersect(i.Exchanges, exchanges)
+	i.Warnings = lo.PickByKeys(i.Warnings, i.Exchanges)
+	i.Tickers = lo.PickByKeys(i.Tickers, i.Exchanges)
+	return i
+}
+
+func (i NetworkInfo) GetAddressLink(address string) string {
+	return explorerLink(i.AddressExplorer, address)
+}
+
+func (i NetworkInfo) GetTxLink(hash string) string {
+	return explorerLink(i.TxExplorer, hash)
+}
+
+// explorerLink fills the template placeholder, "{}" or "%s", with the value.
+// Templates without one get the value appended.
+func explorerLink(template, value string) string {
+	if template == "" || value == "" {
+		return ""
+	}
+
+	for _, placeholder := range []string{"{}", "%s"} {
+		if strings.Contains(template, placeholder) {
+			return strings.Replace(template, placeholder, url.PathEscape(value), 1)
+		}
+	}
+	return template + url.PathEscape(value)
+}
+
+func (i NetworkInfo) IsSupportedBy(exchange string) bool {
+	return slices.Contains(i.Exchanges, exchange)
+}
+
+// ValidateExtraId checks an extra id (memo, destination tag...) given for an
+// address on the network. An empty extra id is always valid.
+func (i NetworkInfo) ValidateExtraId(pair NetworkPair, extraId string) *apierrors.ApiError {
+	if extraId == "" {
+		return nil
+	}
+
+	if !i.HasExtraId {
+		return apierrors.NewApiError(apierrors.BadRequest,
+			fmt.Errorf("network %s does not support extra id", pair))
+	}
+
+	if i.ExtraIdValidation == "" {
+		return nil
+	}
+
+	regexp, err := regexp.Compile(i.ExtraIdValidation)
+	if err != nil {
+		return apierrors.NewApiError(apierrors.InternalServer, err)
+	}
+
+	if !regexp.MatchString(extraId) {
+		return apierrors.NewApiError(apierrors.BadRequest,
+			fmt.Errorf("invalid extra id for network %s", pair))
+	}
+	return nil
+}
+
 func newNetworks() Networks {
 	return Networks{
-		lookup: map[NetworkPair]bool{},
+		lookup: map[NetworkPair]NetworkInfo{},
 	}
 }
 
 type Networks struct {
-	lookup map[NetworkPair]bool
+	lookup map[NetworkPair]NetworkInfo
 	first  NetworkPair
 }
 
 func (n *Networks) ensureInitialized() {
 	if n.lookup == nil {
-		n.lookup = make(map[NetworkPair]bool)
+		n.lookup = make(map[NetworkPair]NetworkInfo)
 	}
 }
 
 func (n *Networks) Add(symbol, network string) *Networks {
+	return n.AddWithInfo(symbol, network, NetworkInfo{})
+}
+
+// AddWithInfo adds the network, merging its info with the one already known.
+func (n *Networks) AddWithInfo(symbol, network string, info NetworkInfo) *Networks {
 	n.ensureInitialized()
+	pair := newPair(symbol, network)
 	if len(n.lookup) == 0 {
-		n.first = newPair(symbol, network)
+		n.first = pair
 	}
-	n.lookup[newPair(symbol, network)] = true
+	n.lookup[pair] = n.lookup[pair].Merge(info)
 	return n
 }
 
+func (n *Networks) GetInfo(pair NetworkPair) NetworkInfo {
+	n.ensureInitialized()
+	return n.lookup[pair]
+}
+
 func (n *Networks) Has(symbol, network string) bool {
 	n.ensureInitialized()
 	_, ok := n.lookup[newPair(symbol, network)]
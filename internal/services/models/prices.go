@@ -0,0 +1,101 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// CandleInterval is the time span a price candle covers.
+type CandleInterval string
+
+const (
+	CandleMinute CandleInterval = "1m"
+	CandleHour   CandleInterval = "1h"
+	CandleDay    CandleInterval = "1d"
+)
+
+var candleDurations = map[CandleInterval]time.Duration{
+	CandleMinute: time.Minute,
+	CandleHour:   time.Hour,
+	CandleDay:    24 * time.Hour,
+}
+
+// NewCandleInterval defaults to hourly candles when no interval is given.
+func NewCandleInterval(interval string) CandleInterval {
+	if _, ok := candleDurations[CandleInterval(interval)]; ok {
+		return CandleInterval(interval)
+	}
+	return CandleHour
+}
+
+// GetOpenTime returns the start of the candle holding t, days start at
+// midnight UTC.
+func (i CandleInterval) GetOpenTime(t time.Time) time.Time {
+	return t.UTC().Truncate(candleDurations[i])
+}
+
+// PriceSnapshot is the USD price of a currency at a point in time.
+type PriceSnapshot struct {
+	Symbol string
+	Price  float64
+	// Change24h is the price change over the previous day, in percent.
+	Change24h float64
+	TakenAt   time.Time
+}
+
+// NewPriceSnapshots takes the snapshots of the priced currencies.
+func NewPriceSnapshots(currencies []Currency, takenAt time.Time) []PriceSnapshot {
+	priced := lo.Filter(currencies, func(currency Currency, _ int) bool {
+		return currency.Price > 0
+	})
+	return lo.Map(priced, func(currency Currency, _ int) PriceSnapshot {
+		return PriceSnapshot{
+			Symbol:    currency.GetLowerSymbol(),
+			Price:     currency.Price,
+			Change24h: currency.Change24h,
+			TakenAt:   takenAt.UTC(),
+		}
+	})
+}
+
+// GetCandles returns the snapshot as a single price candle of every interval,
+// to be merged into the candles already open.
+func (s PriceSnapshot) GetCandles() []Candle {
+	intervals := lo.Keys(candleDurations)
+	slices.Sort(intervals)
+	return lo.Map(intervals, func(interval CandleInterval, _ int) Candle {
+		return Candle{
+			Symbol:   s.Symbol,
+			Interval: interval,
+			OpenTime: interval.GetOpenTime(s.TakenAt),
+			Open:     s.Price,
+			High:     s.Price,
+			Low:      s.Price,
+			Close:    s.Price,
+		}
+	})
+}
+
+// Candle holds the open, high, low and close prices of a currency over an
+// interval.
+type Candle struct {
+	Symbol   string
+	Interval CandleInterval
+	OpenTime time.Time
+	Open     float64
+	High     float64
+	Low      float64
+	Close    float64
+}
+
+// PriceHistory is the latest price of a currency along with its candles,
+// oldest first.
+type PriceHistory struct {
+	Symbol    string
+	Price     float64
+	Change24h float64
+	Interval  CandleInterval
+	Candles   []Candle
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPriceSnapshots(t *testing.T) {
+	takenAt := time.Date(2024, 5, 17, 13, 42, 31, 0, time.UTC)
+	currencies := []Currency{
+		{Symbol: "BTC", Price: 64000, Change24h: 1.5},
+		{Symbol: "new"},
+	}
+
+	snapshots := NewPriceSnapshots(currencies, takenAt)
+	if len(snapshots) != 1 {
+		t.Fatalf("expected only the priced currency, got %+v", snapshots)
+	}
+	if snapshot := snapshots[0]; snapshot.Symbol != "btc" || snapshot.Change24h != 1.5 || !snapshot.TakenAt.Equal(takenAt) {
+		t.Errorf("unexpected snapshot: %+v", snapshot)
+	}
+
+	want := map[CandleInterval]time.Time{
+		CandleMinute: time.Date(2024, 5, 17, 13, 42, 0, 0, time.UTC),
+		CandleHour:   time.Date(2024, 5, 17, 13, 0, 0, 0, time.UTC),
+		CandleDay:    time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC),
+	}
+	candles := snapshots[0].GetCandles()
+	if len(candles) != len(want) {
+		t.Fatalf("expected a candle per interval, got %+v", candles)
+	}
+	for _, candle := range candles {
+		if !candle.OpenTime.Equal(want[candle.Interval]) {
+			t.Errorf("expected the %s candle to open at %s, got %s", candle.Interval, want[candle.Interval],
+				candle.OpenTime)
+		}
+		if candle.Open != 64000 || candle.High != 64000 || candle.Low != 64000 || candle.Close != 64000 {
+			t.Errorf("unexpected %s candle prices: %+v", candle.Interval, candle)
+		}
+	}
+}
+
+func TestNewCandleInterval(t *testing.T) {
+	if interval := NewCandleInterval("1d"); interval != CandleDay {
+		t.Errorf("expected daily candles, got %s", interval)
+	}
+	if interval := NewCandleInterval(""); interval != CandleHour {
+		t.Errorf("expected hourly candles by default, got %s", interval)
+	}
+}
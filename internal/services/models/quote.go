@@ -1,29 +1,161 @@
 package models
 
+import "time"
+
+type RateMode string
+
+const (
+	RateFloating RateMode = "floating"
+	RateFixed    RateMode = "fixed"
+)
+
+// NewRateMode defaults to a floating rate when no mode is given.
+func NewRateMode(mode string) RateMode {
+	if RateMode(mode) == RateFixed {
+		return RateFixed
+	}
+	return RateFloating
+}
+
+func (r RateMode) IsFixed() bool {
+	return r == RateFixed
+}
+
+// AmountType tells which side of a quote is fixed: the amount sent, or the
+// amount received for reverse quotes.
+type AmountType string
+
+const (
+	AmountFrom AmountType = "from"
+	AmountTo   AmountType = "to"
+)
+
+// NewAmountType defaults to fixing the amount sent when no type is given.
+func NewAmountType(amountType string) AmountType {
+	if AmountType(amountType) == AmountTo {
+		return AmountTo
+	}
+	return AmountFrom
+}
+
+func (a AmountType) IsReverse() bool {
+	return a == AmountTo
+}
+
 // Quote representa una cotización de un exchange
 type Quote struct {
-	From       NetworkPair `json:"from"`
-	To         NetworkPair `json:"to"`
-	Amount     float64     `json:"amount"`
-	Exchange   string      `json:"exchange"`
-	Difference float64     `json:"difference"`
+	From         NetworkPair `json:"from"`
+	To           NetworkPair `json:"to"`
+	FromAmount   float64     `json:"fromAmount"`
+	Amount       float64     `json:"amount"`
+	AmountType   AmountType  `json:"amountType"`
+	Exchange     string      `json:"exchange"`
+	Difference   float64     `json:"difference"`
+	Unpriced     bool        `json:"-"`
+	Limits       Limits      `json:"limits"`
+	WithinLimits bool        `json:"withinLimits"`
+	RateMode     RateMode    `json:"rateMode"`
+	RateId       string      `json:"rateId,omitempty"`
+	ValidUntil   time.Time   `json:"validUntil,omitzero"`
+	Warnings     Warnings    `json:"warnings"`
+	Best         bool        `json:"best"`
 }
 
 func (q Quote) IsEmpty() bool {
-	return q.Amount == 0
+	return q.Amount == 0 || q.FromAmount == 0
+}
+
+func (q Quote) WithLimits(limits Limits, amount float64) Quote {
+	q.Limits = limits
+	q.WithinLimits = limits.Contains(amount)
+	return q
 }
 
+// UpdateFromPrice sets the quote rate against the market price. Quotes of a
+// currency without price are flagged Unpriced, with no difference.
 func (q Quote) UpdateFromPrice(input float64, currs map[NetworkPair]Currency) Quote {
 	fromPrice := currs[q.From].Price
 	toPrice := currs[q.To].Price
+	q.Unpriced = input == 0 || fromPrice == 0 || toPrice == 0
+	if q.Unpriced {
+		q.Difference = 0
+		return q
+	}
 	theoreticalPrice := input * fromPrice / toPrice
 	q.Difference = (q.Amount - theoreticalPrice) / theoreticalPrice * 100
 	return q
 }
 
+// WithWarnings sets what the exchange warns about sending the from currency
+// and receiving the to currency on their networks.
+func (q Quote) WithWarnings(currs map[NetworkPair]Currency) Quote {
+	q.Warnings = NewSwapWarnings(q.Exchange, q.From, q.To, currs)
+	return q
+}
+
+// NewSwapWarnings joins the exchange warnings for the sent and received sides
+// of a pair.
+func NewSwapWarnings(exchange string, from, to NetworkPair, currs map[NetworkPair]Currency) Warnings {
+	fromCurr, toCurr := currs[from], currs[to]
+	return Warnings{
+		From: fromCurr.GetNetworkInfo(from).GetWarnings(exchange).From,
+		To:   toCurr.GetNetworkInfo(to).GetWarnings(exchange).To,
+	}
+}
+
+func NewQuoteRequest(from, to NetworkPair, amount float64, rate RateMode) QuoteRequest {
+	return QuoteRequest{
+		From:       from,
+		To:         to,
+		Amount:     amount,
+		Rate:       rate,
+		AmountType: AmountFrom,
+	}
+}
+
 // QuoteRequest representa una solicitud de cotización
 type QuoteRequest struct {
-	From   string  `json:"from" validate:"required"`
-	To     string  `json:"to" validate:"required"`
-	Amount float64 `json:"amount" validate:"required,gt=0"`
+	From       NetworkPair `json:"from" validate:"required"`
+	To         NetworkPair `json:"to" validate:"required"`
+	Amount     float64     `json:"amount" validate:"required,gt=0"`
+	Rate       RateMode    `json:"rate"`
+	AmountType AmountType  `json:"amountType"`
+}
+
+// WithAmountType sets which side Amount is, reverse requests ask how much to
+// send to receive Amount.
+func (r QuoteRequest) WithAmountType(amountType AmountType) QuoteRequest {
+	r.AmountType = amountType
+	return r
+}
+
+// NewQuote builds the quote answered by an exchange for the request, from the
+// amount it estimated for the side that isn't fixed.
+func (r QuoteRequest) NewQuote(exchange string, estimation float64) Quote {
+	quote := Quote{
+		From:       r.From,
+		To:         r.To,
+		FromAmount: r.Amount,
+		Amount:     estimation,
+		AmountType: NewAmountType(string(r.AmountType)),
+		Exchange:   exchange,
+		RateMode:   r.Rate,
+	}
+	if r.AmountType.IsReverse() {
+		quote.FromAmount, quote.Amount = estimation, r.Amount
+	}
+	return quote
+}
+
+func (q Quote) WithRate(rateId string, validUntil time.Time) Quote {
+	q.RateId = rateId
+	q.ValidUntil = validUntil
+	return q
+}
+
+// ScaledTo returns the quote for another requested amount at the same rate.
+func (q Quote) ScaledTo(requested, amount float64) Quote {
+	q.FromAmount = q.FromAmount * amount / requested
+	q.Amount = q.Amount * amount / requested
+	return q.WithLimits(q.Limits, q.FromAmount)
 }
@@ -0,0 +1,22 @@
+package models
+
+import "testing"
+
+func TestQuote_UpdateFromPrice(t *testing.T) {
+	btc, eth := NewNetworkPair("btc", "btc"), NewNetworkPair("eth", "eth")
+	currs := map[NetworkPair]Currency{
+		btc: NewCurrency("A", "btc", "btc", "Bitcoin", "", "", true).WithPrice(60000),
+		eth: NewCurrency("A", "eth", "eth", "Ether", "", "", true).WithPrice(3000),
+	}
+
+	quote := Quote{From: btc, To: eth, FromAmount: 1, Amount: 19.8}.UpdateFromPrice(1, currs)
+	if quote.Unpriced || quote.Difference > -0.99 || quote.Difference < -1.01 {
+		t.Errorf("expected a 1%% lower rate than the market, got %+v", quote)
+	}
+
+	delete(currs, eth)
+	quote = quote.UpdateFromPrice(1, currs)
+	if !quote.Unpriced || quote.Difference != 0 {
+		t.Errorf("expected an unpriced quote, got %+v", quote)
+	}
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// RankStrategy sets how the quotes are ordered, best first.
+type RankStrategy string
+
+const (
+	// RankPayout ranks by the weighted amount received, or sent for reverse
+	// quotes.
+	RankPayout RankStrategy = "payout"
+	// RankMarket ranks by the rate compared to the market price.
+	RankMarket RankStrategy = "market"
+	// RankFastest ranks by how fast the exchange answered.
+	RankFastest RankStrategy = "fastest"
+)
+
+// NewRankStrategy defaults to ranking by payout when no strategy is given.
+func NewRankStrategy(strategy string) RankStrategy {
+	switch RankStrategy(strategy) {
+	case RankMarket, RankFastest:
+		return RankStrategy(strategy)
+	default:
+		return RankPayout
+	}
+}
+
+// Rank orders the quotes by the strategy and flags the top one as best. The
+// quotes within the exchange limits always go before the others, weight is
+// the payout factor of each exchange. Unpriced quotes go last when ranking by
+// market rate.
+func (r QuoteResult) Rank(strategy RankStrategy, weight func(exchange string) float64) QuoteResult {
+	latencies := map[string]float64{}
+	for _, diagnostic := range r.Exchanges {
+		latencies[diagnostic.Exchange] = float64(diagnostic.Latency)
+	}
+
+	payout := func(q Quote) float64 {
+		if q.AmountType.IsReverse() {
+			return -q.FromAmount / weight(q.Exchange)
+		}
+		return q.Amount * weight(q.Exchange)
+	}
+	// score is higher for the better quotes
+	score := func(q Quote) float64 {
+		switch strategy {
+		case RankMarket:
+			return q.Difference
+		case RankFastest:
+			return -latencies[q.Exchange]
+		default:
+			return payout(q)
+		}
+	}
+
+	// ranked is false for the quotes the strategy can't score, put after the
+	// others
+	ranked := func(q Quote) bool {
+		return strategy != RankMarket ||
+			!q.Unpriced && !math.IsNaN(q.Difference) && !math.IsInf(q.Difference, 0)
+	}
+
+	quotes := slices.Clone(r.Quotes)
+	sort.SliceStable(quotes, func(i, j int) bool {
+		a, b := quotes[i], quotes[j]
+		if a.WithinLimits != b.WithinLimits {
+			return a.WithinLimits
+		}
+		if ranked(a) != ranked(b) {
+			return ranked(a)
+		}
+		if score(a) != score(b) {
+			return score(a) > score(b)
+		}
+		return payout(a) > payout(b)
+	})
+
+	for i := range quotes {
+		quotes[i].Best = i == 0
+	}
+	r.Quotes = quotes
+	return r
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestQuoteResult_Rank(t *testing.T) {
+	result := QuoteResult{
+		Quotes: []Quote{
+			{Exchange: "A", Amount: 100, Difference: -1, WithinLimits: true},
+			{Exchange: "B", Amount: 105, Difference: -3, WithinLimits: true},
+			{Exchange: "C", Amount: 98, Difference: 0.5, WithinLimits: true},
+			{Exchange: "D", Amount: 200, Difference: 5, WithinLimits: false},
+			{Exchange: "E", Amount: 99, Difference: math.Inf(1), Unpriced: true, WithinLimits: true},
+		},
+		Exchanges: []ExchangeDiagnostic{
+			NewExchangeDiagnostic("A", ExchangeStatusOk, 300*time.Millisecond),
+			NewExchangeDiagnostic("B", ExchangeStatusOk, 900*time.Millisecond),
+			NewExchangeDiagnostic("C", ExchangeStatusOk, 100*time.Millisecond),
+			NewExchangeDiagnostic("D", ExchangeStatusOk, 50*time.Millisecond),
+			NewExchangeDiagnostic("E", ExchangeStatusOk, 200*time.Millisecond),
+		},
+	}
+	weights := map[string]float64{"B": 0.9}
+	weight := func(exchange string) float64 {
+		if w, ok := weights[exchange]; ok {
+			return w
+		}
+		return 1
+	}
+
+	tests := []struct {
+		strategy RankStrategy
+		want     []string
+	}{
+		{strategy: RankPayout, want: []string{"A", "E", "C", "B", "D"}},
+		{strategy: RankMarket, want: []string{"C", "A", "B", "E", "D"}},
+		{strategy: RankFastest, want: []string{"C", "E", "A", "B", "D"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			ranked := result.Rank(tt.strategy, weight)
+			for i, quote := range ranked.Quotes {
+				if quote.Exchange != tt.want[i] {
+					t.Fatalf("expected order %v, got %s at %d", tt.want, quote.Exchange, i)
+				}
+				if quote.Best != (i == 0) {
+					t.Errorf("%s: unexpected best %t", quote.Exchange, quote.Best)
+				}
+			}
+		})
+	}
+
+	if result.Quotes[0].Best {
+		t.Error("ranking shouldn't change the original quotes")
+	}
+}
+
+func TestNewRankStrategy(t *testing.T) {
+	for input, want := range map[string]RankStrategy{
+		"":        RankPayout,
+		"market":  RankMarket,
+		"fastest": RankFastest,
+		"cheap":   RankPayout,
+	} {
+		if got := NewRankStrategy(input); got != want {
+			t.Errorf("NewRankStrategy(%q): expected %s, got %s", input, want, got)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package models
+
+import "sort"
+
+// Route swaps a pair no exchange quotes directly in two legs, through a
+// bridge asset. Legs are in the order they have to be run.
+type Route struct {
+	Via           NetworkPair
+	Legs          []Quote
+	FromAmount    float64
+	Amount        float64
+	EffectiveRate float64
+	// Difference is the route rate against the market price, in percent.
+	Difference float64
+	// FeesUsd is the value lost across the legs, at market prices.
+	FeesUsd float64
+}
+
+func NewRoute(via NetworkPair, legs []Quote, currs map[NetworkPair]Currency) Route {
+	first, last := legs[0], legs[len(legs)-1]
+	route := Route{
+		Via:        via,
+		Legs:       legs,
+		FromAmount: first.FromAmount,
+		Amount:     last.Amount,
+	}
+	if route.FromAmount != 0 {
+		route.EffectiveRate = route.Amount / route.FromAmount
+	}
+
+	fromValue := route.FromAmount * currs[first.From].Price
+	toValue := route.Amount * currs[last.To].Price
+	if fromValue != 0 {
+		route.Difference = (toValue - fromValue) / fromValue * 100
+		route.FeesUsd = fromValue - toValue
+	}
+
+	return route
+}
+
+// ScaledTo returns the route for another requested amount at the same rates.
+func (r Route) ScaledTo(requested, amount float64) Route {
+	legs := make([]Quote, len(r.Legs))
+	for i, leg := range r.Legs {
+		legs[i] = leg.ScaledTo(requested, amount)
+	}
+	r.Legs = legs
+
+	ratio := amount / requested
+	r.FromAmount *= ratio
+	r.Amount *= ratio
+	r.FeesUsd *= ratio
+	return r
+}
+
+// SortRoutes orders the routes best first: the most received, or the least
+// sent for reverse requests.
+func SortRoutes(routes []Route, amountType AmountType) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		if amountType.IsReverse() {
+			return routes[i].FromAmount < routes[j].FromAmount
+		}
+		return routes[i].Amount > routes[j].Amount
+	})
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewRoute(t *testing.T) {
+	xmr := NewNetworkPair("xmr", "xmr")
+	btc := NewNetworkPair("btc", "btc")
+	ton := NewNetworkPair("ton", "ton")
+	currs := map[NetworkPair]Currency{
+		xmr: {Price: 150},
+		ton: {Price: 5},
+	}
+
+	route := NewRoute(btc, []Quote{
+		{From: xmr, To: btc, FromAmount: 2, Amount: 0.005},
+		{From: btc, To: ton, FromAmount: 0.005, Amount: 58},
+	}, currs)
+
+	if route.FromAmount != 2 || route.Amount != 58 || route.EffectiveRate != 29 {
+		t.Errorf("unexpected route amounts: %+v", route)
+	}
+	if route.FeesUsd != 10 || math.Abs(route.Difference+10.0/3) > 1e-9 {
+		t.Errorf("unexpected route fees: %+v", route)
+	}
+
+	scaled := route.ScaledTo(2, 4)
+	if scaled.FromAmount != 4 || scaled.Amount != 116 || scaled.Legs[1].FromAmount != 0.01 {
+		t.Errorf("unexpected scaled route: %+v", scaled)
+	}
+	if route.Legs[1].FromAmount != 0.005 {
+		t.Error("the original legs shouldn't change")
+	}
+}
+
+func TestSortRoutes(t *testing.T) {
+	routes := []Route{{FromAmount: 2, Amount: 50}, {FromAmount: 1, Amount: 40}, {FromAmount: 3, Amount: 60}}
+
+	SortRoutes(routes, AmountFrom)
+	if routes[0].Amount != 60 || routes[2].Amount != 40 {
+		t.Errorf("expected the most received first, got %+v", routes)
+	}
+
+	SortRoutes(routes, AmountTo)
+	if routes[0].FromAmount != 1 || routes[2].FromAmount != 3 {
+		t.Errorf("expected the least sent first, got %+v", routes)
+	}
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"math"
+	"sort"
+
+	"github.com/samber/lo"
+)
+
+// SplitQuote spreads the amount sent across several exchanges, each part with
+// its own quote, to receive more than a single exchange gives for it.
+type SplitQuote struct {
+	Quotes     []Quote
+	FromAmount float64
+	Amount     float64
+	// Gain is what the split receives over the best single exchange, all of
+	// it when no exchange takes the whole amount.
+	Gain float64
+}
+
+// NewSplitQuote allocates the buckets of the amount to at most maxParts
+// exchanges so that the total received is the highest. buckets[k] holds the
+// quotes for k+1 buckets, out of len(buckets), of the amount. It fails when
+// the quotes within limits can't cover the whole amount.
+func NewSplitQuote(buckets [][]Quote, maxParts int) (SplitQuote, bool) {
+	total := len(buckets)
+	offers := map[string][]Quote{}
+	for k, quotes := range buckets {
+		for _, quote := range quotes {
+			if quote.IsEmpty() || !quote.WithinLimits {
+				continue
+			}
+			if _, ok := offers[quote.Exchange]; !ok {
+				offers[quote.Exchange] = make([]Quote, total+1)
+			}
+			offers[quote.Exchange][k+1] = quote
+		}
+	}
+	exchanges := lo.Keys(offers)
+	sort.Strings(exchanges)
+
+	// best[u][p] is the most received for u buckets over p exchanges, and
+	// choices[i][u][p] the buckets exchange i takes to reach it
+	best := newSplitTable(total, maxParts, math.Inf(-1))
+	best[0][0] = 0
+	choices := make([][][]int, len(exchanges))
+	for i, exchange := range exchanges {
+		next := newSplitTable(total, maxParts, 0.0)
+		for u := range best {
+			copy(next[u], best[u])
+		}
+		choices[i] = newSplitTable(total, maxParts, 0)
+		for u := 0; u < total; u++ {
+			for p := 0; p < maxParts; p++ {
+				if math.IsInf(best[u][p], -1) {
+					continue
+				}
+				for k := 1; u+k <= total; k++ {
+					offer := offers[exchange][k]
+					if offer.IsEmpty() {
+						continue
+					}
+					if amount := best[u][p] + offer.Amount; amount > next[u+k][p+1] {
+						next[u+k][p+1] = amount
+						choices[i][u+k][p+1] = k
+					}
+				}
+			}
+		}
+		best = next
+	}
+
+	parts := 0
+	for p := 1; p <= maxParts; p++ {
+		if best[total][p] > best[total][parts] {
+			parts = p
+		}
+	}
+	if parts == 0 {
+		return SplitQuote{}, false
+	}
+
+	split := SplitQuote{Quotes: []Quote{}}
+	for i, u := len(exchanges)-1, total; i >= 0; i-- {
+		k := choices[i][u][parts]
+		if k == 0 {
+			continue
+		}
+		quote := offers[exchanges[i]][k]
+		split.Quotes = append(split.Quotes, quote)
+		split.FromAmount += quote.FromAmount
+		split.Amount += quote.Amount
+		u, parts = u-k, parts-1
+	}
+	sort.SliceStable(split.Quotes, func(i, j int) bool {
+		return split.Quotes[i].FromAmount > split.Quotes[j].FromAmount
+	})
+
+	single := lo.Max(lo.Map(exchanges, func(exchange string, _ int) float64 {
+		return offers[exchange][total].Amount
+	}))
+	split.Gain = split.Amount - single
+	return split, true
+}
+
+func newSplitTable[T any](total, maxParts int, value T) [][]T {
+	table := make([][]T, total+1)
+	for u := range table {
+		table[u] = make([]T, maxParts+1)
+		for p := range table[u] {
+			table[u][p] = value
+		}
+	}
+	return table
+}
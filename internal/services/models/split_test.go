@@ -0,0 +1,103 @@
+package models
+
+import "testing"
+
+func TestNewSplitQuote(t *testing.T) {
+	// A is the best on small amounts but slips on large ones, C only takes one bucket
+	quote := func(exchange string, fromAmount, amount float64) Quote {
+		return Quote{Exchange: exchange, FromAmount: fromAmount, Amount: amount, WithinLimits: true}
+	}
+	buckets := [][]Quote{
+		{quote("A", 1, 100), quote("B", 1, 98), quote("C", 1, 96)},
+		{quote("A", 2, 190), quote("B", 2, 194)},
+		{quote("A", 3, 270), quote("B", 3, 288)},
+		{quote("A", 4, 340), quote("B", 4, 360)},
+	}
+
+	tests := []struct {
+		name     string
+		maxParts int
+		want     map[string]float64
+		amount   float64
+	}{
+		{name: "single part", maxParts: 1, want: map[string]float64{"B": 4}, amount: 360},
+		{name: "two parts", maxParts: 2, want: map[string]float64{"A": 1, "B": 3}, amount: 388},
+		{name: "three parts", maxParts: 3, want: map[string]float64{"A": 1, "B": 2, "C": 1}, amount: 390},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			split, ok := NewSplitQuote(buckets, tt.maxParts)
+			if !ok {
+				t.Fatal("expected a split")
+			}
+			if split.Amount != tt.amount || split.FromAmount != 4 || split.Gain != tt.amount-360 {
+				t.Errorf("unexpected split totals: %+v", split)
+			}
+			if len(split.Quotes) != len(tt.want) {
+				t.Fatalf("expected %d parts, got %+v", len(tt.want), split.Quotes)
+			}
+			for _, quote := range split.Quotes {
+				if quote.FromAmount != tt.want[quote.Exchange] {
+					t.Errorf("expected %s to take %f, got %f", quote.Exchange, tt.want[quote.Exchange],
+						quote.FromAmount)
+				}
+			}
+		})
+	}
+}
+
+func TestNewSplitQuote_OutOfLimits(t *testing.T) {
+	buckets := [][]Quote{
+		{{Exchange: "A", FromAmount: 1, Amount: 10, WithinLimits: true}},
+		{{Exchange: "A", FromAmount: 2, Amount: 20, WithinLimits: false}},
+	}
+
+	if split, ok := NewSplitQuote(buckets, 2); ok {
+		t.Errorf("expected no split, got %+v", split)
+	}
+}
+
+func TestSwap_WithChildren(t *testing.T) {
+	child := func(status string) Swap {
+		return Swap{Status: status, PayinAmount: 1, PayoutAmount: 10}
+	}
+
+	tests := []struct {
+		name     string
+		children []Swap
+		want     string
+	}{
+		{
+			name:     "least advanced part",
+			children: []Swap{child(SwapStatusSending), child(SwapStatusConfirming), child(SwapStatusCompleted)},
+			want:     SwapStatusConfirming,
+		},
+		{
+			name:     "all completed",
+			children: []Swap{child(SwapStatusCompleted), child(SwapStatusCompleted)},
+			want:     SwapStatusCompleted,
+		},
+		{
+			name:     "some completed",
+			children: []Swap{child(SwapStatusCompleted), child(SwapStatusRefunded)},
+			want:     SwapStatusPartial,
+		},
+		{
+			name:     "none completed",
+			children: []Swap{child(SwapStatusExpired), child(SwapStatusRefunded)},
+			want:     SwapStatusFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			swap := NewSplitSwap(NetworkPair{}, NetworkPair{}, "", "", nil)
+			swap.WithChildren(tt.children)
+			if swap.Status != tt.want {
+				t.Errorf("expected status %s, got %s (%s)", tt.want, swap.Status, swap.Reason)
+			}
+			if swap.PayinAmount != float64(len(tt.children)) || swap.PayoutAmount != float64(10*len(tt.children)) {
+				t.Errorf("unexpected amounts: %f to %f", swap.PayinAmount, swap.PayoutAmount)
+			}
+		})
+	}
+}
@@ -4,19 +4,51 @@ import (
 	"cryptoswap/internal/lib/apierrors"
 	"cryptoswap/internal/lib/ids"
 	"errors"
+	"fmt"
 	"regexp"
+	"strings"
 	"time"
+
+	"github.com/samber/lo"
 )
 
 const (
-	swapStatusPending   = "PENDING"
-	swapStatusCompleted = "COMPLETED"
-	swapStatusFailed    = "FAILED"
+	SwapStatusPending    = "PENDING"
+	SwapStatusConfirming = "CONFIRMING"
+	SwapStatusExchanging = "EXCHANGING"
+	SwapStatusSending    = "SENDING"
+	SwapStatusCompleted  = "COMPLETED"
+	SwapStatusFailed     = "FAILED"
+	SwapStatusRefunded   = "REFUNDED"
+	SwapStatusExpired    = "EXPIRED"
+	// SwapStatusPartial is only reached by split swaps, when some of their
+	// parts completed and the others didn't.
+	SwapStatusPartial = "PARTIAL"
 )
 
-// TODO: validate min amount -> do a swap processor with repository calls
+// SplitExchange is the exchange of the split swaps, their parts run on the
+// exchanges of their child swaps.
+const SplitExchange = "split"
+
+var finishedStatuses = map[string]bool{
+	SwapStatusCompleted: true,
+	SwapStatusFailed:    true,
+	SwapStatusRefunded:  true,
+	SwapStatusExpired:   true,
+	SwapStatusPartial:   true,
+}
+
+// runningStatuses orders the statuses of the swaps in progress, a split swap
+// is as far as its least advanced part.
+var runningStatuses = map[string]int{
+	SwapStatusPending:    0,
+	SwapStatusConfirming: 1,
+	SwapStatusExchanging: 2,
+	SwapStatusSending:    3,
+}
+
 func NewSwap(payinAmount float64, from, to NetworkPair, toAdress,
-	refundAddress, exchange string) Swap {
+	refundAddress, exchange, rateId string) Swap {
 	return Swap{
 		Id:            ids.NewSwapRequestId(),
 		From:          from,
@@ -24,26 +56,184 @@ func NewSwap(payinAmount float64, from, to NetworkPair, toAdress,
 		PayinAmount:   payinAmount,
 		ToAddress:     toAdress,
 		RefundAddress: refundAddress,
-		Status:        swapStatusPending,
+		Status:        SwapStatusPending,
 		Exchange:      exchange,
+		RateId:        rateId,
+	}
+}
+
+// NewSplitSwap creates a swap spread over several exchanges, with one child
+// swap per part. The parts are paid and tracked each on its own exchange.
+func NewSplitSwap(from, to NetworkPair, toAdress, refundAddress string, parts []SwapPart) Swap {
+	swap := NewSwap(0, from, to, toAdress, refundAddress, SplitExchange, "")
+	for _, part := range parts {
+		child := NewSwap(part.Amount, from, to, toAdress, refundAddress, part.Exchange, part.RateId)
+		child.ParentId = swap.Id
+		swap.Children = append(swap.Children, child)
+		swap.PayinAmount += part.Amount
 	}
+	return swap
+}
+
+// SwapPart is the amount of a split swap sent to one exchange, at the rate of
+// its quote when fixed.
+type SwapPart struct {
+	Exchange string
+	Amount   float64
+	RateId   string
 }
 
 type Swap struct {
 	Id            string      `json:"id"`
+	ParentId      string      `json:"parentId,omitempty"`
 	From          NetworkPair `json:"from"`
 	To            NetworkPair `json:"to"`
 	ExchangeId    string      `json:"exchangeId"`
 	PayinAmount   float64     `json:"payinAmount"`
 	PayoutAmount  float64     `json:"payoutAmount"`
 	PayoutAddress string      `json:"payoutAddress"`
+	PayoutExtraId string      `json:"payoutExtraId,omitempty"`
 	ToAddress     string      `json:"toAddress"`
+	ToExtraId     string      `json:"toExtraId,omitempty"`
 	RefundAddress string      `json:"refundAddress"`
+	RefundExtraId string      `json:"refundExtraId,omitempty"`
 	Exchange      string      `json:"exchange"`
+	RateId        string      `json:"rateId,omitempty"`
+	PayinHash     string      `json:"payinHash,omitempty"`
+	PayoutHash    string      `json:"payoutHash,omitempty"`
 	Reason        string      `json:"reason"`
 	Status        string      `json:"status"`
 	CreatedAt     time.Time   `json:"createdAt"`
 	UpdatedAt     time.Time   `json:"updatedAt"`
+	Links         SwapLinks   `json:"links"`
+	Warnings      Warnings    `json:"warnings"`
+	Children      []Swap      `json:"children,omitempty"`
+}
+
+// SwapLinks are the block explorer pages of the swap addresses and transfers,
+// empty when the network has no explorer or the transfer didn't happen yet.
+type SwapLinks struct {
+	DepositAddress string `json:"depositAddress,omitempty"`
+	ToAddress      string `json:"toAddress,omitempty"`
+	PayinTx        string `json:"payinTx,omitempty"`
+	PayoutTx       string `json:"payoutTx,omitempty"`
+}
+
+// GetRateMode tells if the swap locks a fixed rate, which is the case when it
+// carries the rate id of a fixed quote.
+func (s Swap) GetRateMode() RateMode {
+	if s.RateId != "" {
+		return RateFixed
+	}
+	return RateFloating
+}
+
+// ExchangeSwap is the swap as created on the exchange side.
+type ExchangeSwap struct {
+	ExchangeId     string
+	DepositAddress string
+	DepositExtraId string
+	PayinAmount    float64
+	PayoutAmount   float64
+}
+
+// ExchangeSwapStatus is the status of a swap as reported by the exchange,
+// already translated to our own statuses.
+type ExchangeSwapStatus struct {
+	Status     string
+	Reason     string
+	PayinHash  string
+	PayoutHash string
+}
+
+// WithHashes sets the transactions of the deposit and of the payout.
+func (e ExchangeSwapStatus) WithHashes(payinHash, payoutHash string) ExchangeSwapStatus {
+	e.PayinHash = payinHash
+	e.PayoutHash = payoutHash
+	return e
+}
+
+// NewExchangeSwapStatus translates an exchange status through its lookup.
+// Unknown statuses are left empty, with the reason telling which one it was.
+func NewExchangeSwapStatus(status string, lookup map[string]string) ExchangeSwapStatus {
+	if mapped, ok := lookup[strings.ToLower(status)]; ok {
+		return ExchangeSwapStatus{Status: mapped}
+	}
+	return ExchangeSwapStatus{
+		Reason: fmt.Sprintf("unknown exchange status %q", status),
+	}
+}
+
+// IsKnown tells if the exchange status could be translated.
+func (e ExchangeSwapStatus) IsKnown() bool {
+	return e.Status != ""
+}
+
+// WithExtraIds sets the memo or destination tag of the payout and refund addresses.
+func (s *Swap) WithExtraIds(toExtraId, refundExtraId string) *Swap {
+	s.ToExtraId = toExtraId
+	s.RefundExtraId = refundExtraId
+	for i := range s.Children {
+		s.Children[i].WithExtraIds(toExtraId, refundExtraId)
+	}
+	return s
+}
+
+// IsSplit tells if the swap is the parent of swaps run on several exchanges.
+func (s Swap) IsSplit() bool {
+	return s.Exchange == SplitExchange
+}
+
+// WithChildren sets the parts of a split swap and rolls their amounts and
+// statuses up to it.
+func (s *Swap) WithChildren(children []Swap) *Swap {
+	s.Children = children
+	s.PayinAmount, s.PayoutAmount = 0, 0
+	for _, child := range children {
+		s.PayinAmount += child.PayinAmount
+		s.PayoutAmount += child.PayoutAmount
+	}
+	s.Status, s.Reason = rollUpStatus(children)
+	return s
+}
+
+// rollUpStatus is the least advanced status of the parts still running. Once
+// all are finished, it's their common status, partial when only some of them
+// completed, or failed otherwise.
+func rollUpStatus(children []Swap) (string, string) {
+	status := ""
+	for _, child := range children {
+		if child.IsFinished() {
+			continue
+		}
+		if status == "" || runningStatuses[child.Status] < runningStatuses[status] {
+			status = child.Status
+		}
+	}
+	if status != "" {
+		return status, ""
+	}
+
+	completed := lo.CountBy(children, func(child Swap) bool {
+		return child.Status == SwapStatusCompleted
+	})
+	statuses := lo.Uniq(lo.Map(children, func(child Swap, _ int) string {
+		return child.Status
+	}))
+	switch {
+	case len(statuses) == 1:
+		return statuses[0], ""
+	case completed > 0:
+		return SwapStatusPartial, fmt.Sprintf("%d of %d parts completed", completed, len(children))
+	default:
+		return SwapStatusFailed, "no part completed"
+	}
+}
+
+func (s *Swap) WithExchangeSwap(exchangeSwap ExchangeSwap) *Swap {
+	s.PayoutExtraId = exchangeSwap.DepositExtraId
+	return s.WithBillingConditions(exchangeSwap.DepositAddress, exchangeSwap.ExchangeId,
+		exchangeSwap.PayoutAmount)
 }
 
 func (s *Swap) WithBillingConditions(payoutAddress, exchangeId string, payoutAmount float64) *Swap {
@@ -54,16 +244,65 @@ func (s *Swap) WithBillingConditions(payoutAddress, exchangeId string, payoutAmo
 }
 
 func (s *Swap) Complete() *Swap {
-	s.Status = swapStatusCompleted
+	s.Status = SwapStatusCompleted
 	return s
 }
 
 func (s *Swap) Fail(reason string) *Swap {
-	s.Status = swapStatusFailed
+	s.Status = SwapStatusFailed
 	s.Reason = reason
 	return s
 }
 
+// WithExchangeStatus moves the swap to the exchange status, an unknown one
+// keeps the current status so the swap never moves back.
+func (s *Swap) WithExchangeStatus(status ExchangeSwapStatus) *Swap {
+	if status.IsKnown() {
+		s.Status = status.Status
+		if status.Reason != "" {
+			s.Reason = status.Reason
+		}
+	}
+	if status.PayinHash != "" {
+		s.PayinHash = status.PayinHash
+	}
+	if status.PayoutHash != "" {
+		s.PayoutHash = status.PayoutHash
+	}
+	return s
+}
+
+// WithWarnings sets the exchange warnings for the swap pair.
+func (s *Swap) WithWarnings(currs map[NetworkPair]Currency) *Swap {
+	s.Warnings = NewSwapWarnings(s.Exchange, s.From, s.To, currs)
+	return s
+}
+
+// WithExplorerLinks builds the explorer links from the networks of the swap.
+func (s *Swap) WithExplorerLinks(from, to Currency) *Swap {
+	fromInfo := from.GetNetworkInfo(s.From)
+	toInfo := to.GetNetworkInfo(s.To)
+	s.Links = SwapLinks{
+		DepositAddress: fromInfo.GetAddressLink(s.PayoutAddress),
+		ToAddress:      toInfo.GetAddressLink(s.ToAddress),
+		PayinTx:        fromInfo.GetTxLink(s.PayinHash),
+		PayoutTx:       toInfo.GetTxLink(s.PayoutHash),
+	}
+	return s
+}
+
+// StopTracking leaves the swap in its status, with the reason its status
+// isn't followed anymore.
+func (s *Swap) StopTracking(reason string) *Swap {
+	s.Reason = reason
+	return s
+}
+
+// IsFinished tells if the swap reached a status it can't move out of.
+func (s Swap) IsFinished() bool {
+	return finishedStatuses[s.Status]
+}
+
 func (s *Swap) HasValidAddress(curr Currency) *apierrors.ApiError {
 	if curr.AddressValidation != "" {
 		regexp, err := regexp.Compile(curr.AddressValidation)
@@ -77,3 +316,11 @@ func (s *Swap) HasValidAddress(curr Currency) *apierrors.ApiError {
 	}
 	return nil
 }
+
+// HasValidExtraIds checks the extra ids against the networks they are sent on.
+func (s *Swap) HasValidExtraIds(from, to Currency) *apierrors.ApiError {
+	if err := to.GetNetworkInfo(s.To).ValidateExtraId(s.To, s.ToExtraId); err != nil {
+		return err
+	}
+	return from.GetNetworkInfo(s.From).ValidateExtraId(s.From, s.RefundExtraId)
+}
@@ -32,7 +32,6 @@ func (c *messagingConsumer) Build() messaging.Handler {
 			return err
 		}
 
-		// TODO: fetch current transaction status
 		if err := c.service.ProcessSwap(ctx, swap); err != nil {
 			return errors.New(err.Error())
 		}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/samber/lo"
 )
 
 func NewHandlers(logger logger.Logger, handler api.ResponseHandler,
@@ -26,6 +27,18 @@ type handlersImpl struct {
 	service currencies.CurrencyService
 }
 
+func (h *handlersImpl) GetV1AnalyticsQuotes(c *gin.Context, params GetV1AnalyticsQuotesParams) {
+	fromPair := toPair(params.FromSymbol, params.FromNetwork)
+	toPair := toPair(params.ToSymbol, params.ToNetwork)
+	analytics, err := h.service.GetQuoteAnalytics(c, fromPair, toPair, toWindow(params.WindowHours))
+	if err != nil {
+		h.handler.Error(c, err)
+		return
+	}
+
+	h.handler.OK(c, http.StatusOK, toQuoteAnalytics(analytics))
+}
+
 func (h *handlersImpl) GetV1Currencies(c *gin.Context, params GetV1CurrenciesParams) {
 	currencies, err := h.service.GetCurrencies(c, toFilter(params))
 	if err != nil {
@@ -36,16 +49,53 @@ func (h *handlersImpl) GetV1Currencies(c *gin.Context, params GetV1CurrenciesPar
 	h.handler.OK(c, http.StatusOK, toCurrencies(currencies))
 }
 
+func (h *handlersImpl) GetV1CurrenciesSymbol(c *gin.Context, symbol Symbol) {
+	currency, err := h.service.GetCurrency(c, symbol)
+	if err != nil {
+		h.handler.Error(c, err)
+		return
+	}
+
+	h.handler.OK(c, http.StatusOK, toCurrency(currency))
+}
+
+func (h *handlersImpl) GetV1CurrenciesSymbolHistory(c *gin.Context, symbol Symbol,
+	params GetV1CurrenciesSymbolHistoryParams) {
+	interval := models.NewCandleInterval(string(lo.FromPtr(params.Interval)))
+	history, err := h.service.GetPriceHistory(c, symbol, interval, lo.FromPtrOr(params.Limit, 100))
+	if err != nil {
+		h.handler.Error(c, err)
+		return
+	}
+
+	h.handler.OK(c, http.StatusOK, toPriceHistory(history))
+}
+
 func (h *handlersImpl) GetV1Quotes(c *gin.Context, params GetV1QuotesParams) {
 	fromPair := toPair(params.FromSymbol, params.FromNetwork)
 	toPair := toPair(params.ToSymbol, params.ToNetwork)
-	quote, err := h.service.GetQuotes(c, fromPair, toPair, params.Amount)
+	request := models.NewQuoteRequest(fromPair, toPair, params.Amount, toRateMode(params.RateMode)).
+		WithAmountType(toAmountType(params.AmountType))
+	result, err := h.service.GetQuotes(c, request, toRankStrategy(params.Rank))
 	if err != nil {
 		h.handler.Error(c, err)
 		return
 	}
 
-	h.handler.OK(c, http.StatusOK, toQuotes(quote))
+	h.handler.OK(c, http.StatusOK, toQuoteResult(result))
+}
+
+func (h *handlersImpl) GetV1QuotesSplit(c *gin.Context, params GetV1QuotesSplitParams) {
+	fromPair := toPair(params.FromSymbol, params.FromNetwork)
+	toPair := toPair(params.ToSymbol, params.ToNetwork)
+	request := models.NewQuoteRequest(fromPair, toPair, params.Amount, toRateMode(params.RateMode))
+	split, err := h.service.GetSplitQuote(c, request, lo.FromPtr(params.Parts))
+	if err != nil {
+		h.handler.Error(c, err)
+		return
+	}
+
+	h.handler.OK(c, http.StatusOK, toSplitQuote(split))
 }
 
 func (h *handlersImpl) GetV1SwapsId(c *gin.Context, id string) {
@@ -67,7 +117,8 @@ func (h *handlersImpl) PostV1Swaps(c *gin.Context) {
 	from := toPairFromRequest(swapRequest.From)
 	to := toPairFromRequest(swapRequest.To)
 	swap := models.NewSwap(swapRequest.Amount, from, to, swapRequest.ToAddress,
-		swapRequest.RefundAddress, swapRequest.Exchange)
+		swapRequest.RefundAddress, swapRequest.Exchange, lo.FromPtr(swapRequest.RateId))
+	swap.WithExtraIds(lo.FromPtr(swapRequest.ToExtraId), lo.FromPtr(swapRequest.RefundExtraId))
 
 	insertedSwap, err := h.service.InsertSwap(c, swap)
 	if err != nil {
@@ -77,3 +128,25 @@ func (h *handlersImpl) PostV1Swaps(c *gin.Context) {
 
 	h.handler.OK(c, http.StatusOK, toSwap(insertedSwap))
 }
+
+func (h *handlersImpl) PostV1SwapsSplit(c *gin.Context) {
+	var splitRequest SplitSwapRequest
+	if err := c.ShouldBindJSON(&splitRequest); err != nil {
+		h.handler.Error(c, apierrors.NewApiError(apierrors.BadRequest, err))
+		return
+	}
+
+	from := toPairFromRequest(splitRequest.From)
+	to := toPairFromRequest(splitRequest.To)
+	swap := models.NewSplitSwap(from, to, splitRequest.ToAddress, splitRequest.RefundAddress,
+		toSwapParts(splitRequest.Parts))
+	swap.WithExtraIds(lo.FromPtr(splitRequest.ToExtraId), lo.FromPtr(splitRequest.RefundExtraId))
+
+	insertedSwap, err := h.service.InsertSplitSwap(c, swap)
+	if err != nil {
+		h.handler.Error(c, err)
+		return
+	}
+
+	h.handler.OK(c, http.StatusOK, toSwap(insertedSwap))
+}
@@ -20,15 +20,30 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get quote analytics
+	// (GET /v1/analytics/quotes)
+	GetV1AnalyticsQuotes(c *gin.Context, params GetV1AnalyticsQuotesParams)
 	// Get currencies
 	// (GET /v1/currencies)
 	GetV1Currencies(c *gin.Context, params GetV1CurrenciesParams)
+	// Get currency
+	// (GET /v1/currencies/{symbol})
+	GetV1CurrenciesSymbol(c *gin.Context, symbol Symbol)
+	// Get currency price history
+	// (GET /v1/currencies/{symbol}/history)
+	GetV1CurrenciesSymbolHistory(c *gin.Context, symbol Symbol, params GetV1CurrenciesSymbolHistoryParams)
 	// Get quote
 	// (GET /v1/quotes)
 	GetV1Quotes(c *gin.Context, params GetV1QuotesParams)
+	// Get split quote
+	// (GET /v1/quotes/split)
+	GetV1QuotesSplit(c *gin.Context, params GetV1QuotesSplitParams)
 	// Create swap
 	// (POST /v1/swaps)
 	PostV1Swaps(c *gin.Context)
+	// Create split swap
+	// (POST /v1/swaps/split)
+	PostV1SwapsSplit(c *gin.Context)
 	// Get swap
 	// (GET /v1/swaps/{id})
 	GetV1SwapsId(c *gin.Context, id string)
@@ -43,6 +58,92 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// GetV1AnalyticsQuotes operation middleware
+func (siw *ServerInterfaceWrapper) GetV1AnalyticsQuotes(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetV1AnalyticsQuotesParams
+
+	// ------------- Required query parameter "fromSymbol" -------------
+
+	if paramValue := c.Query("fromSymbol"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument fromSymbol is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "fromSymbol", c.Request.URL.Query(), &params.FromSymbol)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fromSymbol: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "fromNetwork" -------------
+
+	if paramValue := c.Query("fromNetwork"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument fromNetwork is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "fromNetwork", c.Request.URL.Query(), &params.FromNetwork)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fromNetwork: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "toSymbol" -------------
+
+	if paramValue := c.Query("toSymbol"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument toSymbol is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "toSymbol", c.Request.URL.Query(), &params.ToSymbol)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter toSymbol: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "toNetwork" -------------
+
+	if paramValue := c.Query("toNetwork"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument toNetwork is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "toNetwork", c.Request.URL.Query(), &params.ToNetwork)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter toNetwork: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "windowHours" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "windowHours", c.Request.URL.Query(), &params.WindowHours)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter windowHours: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetV1AnalyticsQuotes(c, params)
+}
+
 // GetV1Currencies operation middleware
 func (siw *ServerInterfaceWrapper) GetV1Currencies(c *gin.Context) {
 
@@ -93,6 +194,73 @@ func (siw *ServerInterfaceWrapper) GetV1Currencies(c *gin.Context) {
 	siw.Handler.GetV1Currencies(c, params)
 }
 
+// GetV1CurrenciesSymbol operation middleware
+func (siw *ServerInterfaceWrapper) GetV1CurrenciesSymbol(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "symbol" -------------
+	var symbol Symbol
+
+	err = runtime.BindStyledParameterWithOptions("simple", "symbol", c.Param("symbol"), &symbol, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter symbol: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetV1CurrenciesSymbol(c, symbol)
+}
+
+// GetV1CurrenciesSymbolHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetV1CurrenciesSymbolHistory(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "symbol" -------------
+	var symbol Symbol
+
+	err = runtime.BindStyledParameterWithOptions("simple", "symbol", c.Param("symbol"), &symbol, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter symbol: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetV1CurrenciesSymbolHistoryParams
+
+	// ------------- Optional query parameter "interval" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "interval", c.Request.URL.Query(), &params.Interval)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter interval: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetV1CurrenciesSymbolHistory(c, symbol, params)
+}
+
 // GetV1Quotes operation middleware
 func (siw *ServerInterfaceWrapper) GetV1Quotes(c *gin.Context) {
 
@@ -176,6 +344,30 @@ func (siw *ServerInterfaceWrapper) GetV1Quotes(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "amountType" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "amountType", c.Request.URL.Query(), &params.AmountType)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter amountType: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "rateMode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "rateMode", c.Request.URL.Query(), &params.RateMode)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rateMode: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "rank" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "rank", c.Request.URL.Query(), &params.Rank)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rank: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -186,6 +378,115 @@ func (siw *ServerInterfaceWrapper) GetV1Quotes(c *gin.Context) {
 	siw.Handler.GetV1Quotes(c, params)
 }
 
+// GetV1QuotesSplit operation middleware
+func (siw *ServerInterfaceWrapper) GetV1QuotesSplit(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetV1QuotesSplitParams
+
+	// ------------- Required query parameter "fromSymbol" -------------
+
+	if paramValue := c.Query("fromSymbol"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument fromSymbol is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "fromSymbol", c.Request.URL.Query(), &params.FromSymbol)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fromSymbol: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "fromNetwork" -------------
+
+	if paramValue := c.Query("fromNetwork"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument fromNetwork is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "fromNetwork", c.Request.URL.Query(), &params.FromNetwork)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter fromNetwork: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "toSymbol" -------------
+
+	if paramValue := c.Query("toSymbol"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument toSymbol is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "toSymbol", c.Request.URL.Query(), &params.ToSymbol)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter toSymbol: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "toNetwork" -------------
+
+	if paramValue := c.Query("toNetwork"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument toNetwork is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "toNetwork", c.Request.URL.Query(), &params.ToNetwork)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter toNetwork: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "amount" -------------
+
+	if paramValue := c.Query("amount"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument amount is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "amount", c.Request.URL.Query(), &params.Amount)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter amount: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "rateMode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "rateMode", c.Request.URL.Query(), &params.RateMode)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter rateMode: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "parts" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "parts", c.Request.URL.Query(), &params.Parts)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter parts: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetV1QuotesSplit(c, params)
+}
+
 // PostV1Swaps operation middleware
 func (siw *ServerInterfaceWrapper) PostV1Swaps(c *gin.Context) {
 
@@ -199,6 +500,19 @@ func (siw *ServerInterfaceWrapper) PostV1Swaps(c *gin.Context) {
 	siw.Handler.PostV1Swaps(c)
 }
 
+// PostV1SwapsSplit operation middleware
+func (siw *ServerInterfaceWrapper) PostV1SwapsSplit(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostV1SwapsSplit(c)
+}
+
 // GetV1SwapsId operation middleware
 func (siw *ServerInterfaceWrapper) GetV1SwapsId(c *gin.Context) {
 
@@ -253,12 +567,22 @@ func RegisterHandlersWithOptions(router gin.IRouter, siAny any, optionsAny any)
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/v1/analytics/quotes", wrapper.GetV1AnalyticsQuotes)
+
 	router.GET(options.BaseURL+"/v1/currencies", wrapper.GetV1Currencies)
 
+	router.GET(options.BaseURL+"/v1/currencies/:symbol", wrapper.GetV1CurrenciesSymbol)
+
+	router.GET(options.BaseURL+"/v1/currencies/:symbol/history", wrapper.GetV1CurrenciesSymbolHistory)
+
 	router.GET(options.BaseURL+"/v1/quotes", wrapper.GetV1Quotes)
 
+	router.GET(options.BaseURL+"/v1/quotes/split", wrapper.GetV1QuotesSplit)
+
 	router.POST(options.BaseURL+"/v1/swaps", wrapper.PostV1Swaps)
 
+	router.POST(options.BaseURL+"/v1/swaps/split", wrapper.PostV1SwapsSplit)
+
 	router.GET(options.BaseURL+"/v1/swaps/:id", wrapper.GetV1SwapsId)
 
 }
@@ -266,25 +590,59 @@ func RegisterHandlersWithOptions(router gin.IRouter, siAny any, optionsAny any)
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+RXS2/jNhD+KwK7tzqW002BrU7Npt3CKJrN1kEuQQrQ0tjmVnyYpLxVA//3YijrTfnR",
-	"dXLZm60ZzvOb4cdnEkuupABhDYmeiYlXwKn7eZNpDSLO8bfSUoG2DJyEJokGYx5oyhJqmRT40eYKSESM",
-	"1UwsyXZE6IaylM5TaEjnUqZABYoZp0vwHhSUDwjAfpH6bxcDs8DdjzcaFiQi34V1IuEui/C2OIBnd8ao",
-	"1jTH/0qz2HlZSM2pJRFJZIbBVqoi43PQqGtyPpepJ6TtiGhYZ0xDQqLHIvBKvUyxWYnS78hTw0Z+T1UQ",
-	"cv4ZYotB/Kq11P1ecDDGX8hOcKWiz3ZZp571gVb48t5j944yT+Sidrqvh7Oimq02HKPfibFqSunWF++n",
-	"TFrw4J3LTNgjwZKwxQJwcI5FF/wTr6gYmIWFlvxIlLsqI9LlSQc6dXIOnZFRmXcjxFZ6vgrOvlDVL2Cs",
-	"gVpIrjs1pBYuLOONqtSJn7sqLPFaUjRn4vqU/iqay8xeF+M7ZBM1TjGqgZqBNaphkYlknz9jqc38ohOx",
-	"gAf2ecpUclofO+BiCanCHTVQ0bQ8akGw2Z9mdC1M7qrX7U2nE91SDsH3T1hnYOxpa4AzwXjGSTR5hQk/",
-	"DImz9v2YDdEOaaBT3opXS11Ra0ELEpG/HunFv5OLn56+f+OBFA6zWLgEYyksjV1LgFOWkqj89LNhaiXF",
-	"2OA+KikFmbmPwaz4mGk8sLJWmSgMmwdwjYOJNVMFuykPojC4vpuSEUlZDMJAfUmSP6b3PaNSgTAy0zGM",
-	"pV6Gu0MmRF2sOrMp9M0HF8FHBQJ/vR1PyIhsQJsikMvxZDzBo2iZKkYi8nY8GV85sNuVa1+4uQzjgrzt",
-	"kLsEV6N2Tr+BDRpqzqR2VGSaFOKHy5umXFFNOVjQhkSPXWu3lEMgF4FdQWk1RwqEsnUGOq+7UNIkh8Tj",
-	"r/OuxzupspTqdg4+f6pQbLns8tG++evYsg0ctk6d3onGi6yGTJpKWtus+G5/1lvMdvuEw2iURJyh/g+T",
-	"STkqUGwvqlTKYtfp8PPu2vE42teV6m3Q99+bnY+/o9aPJ4axz3tBhT2u3tMkKNc3Sk3GOdV5H+woxDFZ",
-	"I+HbPyJOxT8dn4rTBybjg5b80EjgTp2VDLVetlZn8PWD4gIoae+w/9tK49wB3MtD+Vv5YtnfywO5W/ly",
-	"mVfUw7s56stzyOtJRON1Rr94I20HB/3q1Qf9lZbLVCA9oWkwA70BHZSK3TWz3hWo2DB4p7uaKmk8G+bG",
-	"ceBgR1PaO+ZOGvtwOXMGCpCAse9lkp8t1ybd3bZ5HiJx28PT5Vld+6pcFCT55pHUBEYLS+EzS7Z7bywv",
-	"mNyF5bA0TQ7dWKgWTH8p1xYSy3pruTfc8MbqsvWv3Un/B0PlIrp6+VbeSht8kJlIPIvAVOEZ1+ei1PUD",
-	"IQrDVMY0XUljo3eTd8jrnzsPCKrYuPUywTcUXfq61uLqu2Y1Kc/T9r8AAAD//3BaHPHtFQAA",
+	"H4sIAAAAAAAC/+xbX5PUuBH/KirlqvIQszPLcclln7IHd2ErwO2xe/BAEUpj99i6tSUjyTM7oea7pyRZ",
+	"tmzLHg+wQCX7BmP9aXX/+n/vBxzzouQMmJL47AOWcQYFMf88L3jF1PWuBP2/BGQsaKkoZ/gMrwUv0DYD",
+	"hlQGiJiVSAJTiEq0preQREjxwQoBMdANJIhKHGFgVYHP3pjDcIQVx28jrMx9WCpBWYr3EX5MWJIbEkrB",
+	"SxCKgiEvzrk0P6+5KIjCZzjh1SoH3JzBqmIFQp+R0TSbuTTn25kreQnsiKXXtOiRSxQ8ULTwdrhX7yMs",
+	"4H1FBSSaQc32+tL6QZbYqOZEyzu++gNi1fLugikQG5Lr2x3PTzXHT/UZp0mY7ZUQwOLdkPEkSQRI+Yrk",
+	"NCEWDx+G+8mG0JyscvC+rjjPgTD9mRYkheBGRoqRD6C2XNwYGqiCwvzjOwFrfIb/tGhhvKgxvHhhN+i9",
+	"9WFECLLT/y8FjeeCR+6KFc8DJPWkZAhvlrsn+pxw90YBHnrvC0nyZyG4GMqiACnDjOwR5xYGz76NM8JS",
+	"eEJJyrhUNB5eBPr+x0RBysVuaA9eZzuj51AfhRKasD8r9L7iCjxV13DnlcIRFkTBu5wWVEGCI8wr9Y6v",
+	"7Q9SY5qKuKLqXY33VZXfZECSd+sq17wVoIk3OyvmM7hiN4xvWRDSjrggunKiNNyfyw4qKFN/fdSCgjIF",
+	"aY0KRVR1EIKOtVd2dV8qDUXNeT4hU7LSB8qAbm7SJ3S9Bq26AbP9HAhDmvOIr61dZnILAhIrKIlISiiT",
+	"ynwriLgBhQxkI0QZKkHEwLTwZmgN2aTPjmTppHwKcnvsefZNQy78pn9HWg4glezi1jEkeKDbctyRWyIR",
+	"kTcjZ24pe0nUXFu0pezI21OyAfPLCqRCJdnxSiG+DtAyjs3m3Q1Pa0pa8qMe9HoA6MnvELKtYjmjwW9w",
+	"ZA0QjjwLUjFZlSUX2gyE1P2ZtSVDm0luZ3K7oPMcfN/WUhZ8ofNHw1CGMyVIrILAT6gsc7J7MeYWnZy6",
+	"fnGwrO8B4VYJcpEc8OIZkT/bhWE3Puqst0QwylIXMFB9A8kvO8+eMpyv3f591AO7+6JNGJA4a6GeU6ko",
+	"Sw3aa2eKB2IIO22fyZ1X+/ydkOkloQHvzFqBT731ykYMnVBjzvreU5rAw10bovdSW/OnVKrajfeAaOLF",
+	"+RFWHZsH4GV59vBRNrRWhgRUy4xvQBiBlQI2lFcSJWR3vLehXoh7mOAmIPYjwS6R2lbJ2vlpan6/ejKP",
+	"krnRYiMtFxK2HPOeEzUiCQnT2P1AEGCSreGbzrtJ2Ew/3skCp3jr5Yv7CGt3E4oTQWVG4lTqVFFLXvES",
+	"CcJuXBSCo4ChSTpRzQy6J4MJk3HOyx6MZtdbzqcZK2fDNW9c0xQFtQPb22D5Igk+RX96zpOD0nnp1u1N",
+	"pn3c4zfaS/zOFM3nZrBdDzDX0m+pyihrHXcfBz0dagsHHfk40OIOeiM/okn8SKVzq8dR7w01nkeV8JyR",
+	"fKdoHAg3wv55btIgQ8b1I+Ar6UB1poR3JEIm5GIvPuRGf2tC9QnmdZXuKd8i2IDYDYN3Y1be+0Exjo5j",
+	"vZcKB/jfJhazDjWPC50jeBVMUK63/EEOKbLfkcoEr9IMEbQSNNHvlBJUhDjLdyjnXFvONRe22sZ4y486",
+	"qTPulVCBEiogVvluLjde6vuHhPek3aQE9XMOyfolYTdXSmtZGqgk1BmKdggSrXb9ymHk0tLVziay4xnr",
+	"mkjjw/VKkCVnElCNd5dZ2MtMdqJ3ajNiNwUTipeeqXUnrHNOdNCpt+qyZ3ij4eOEn57hMpKJtN6cf4gd",
+	"x4ZUsF5DrOgGXHo6GUzos1HF6DFecA0gf5fJ8OxXJK8A5VwqRGLBpYVwDqlERHWeJaOjgrOuE5/jqCEd",
+	"y7fbBCSH1JChieQisQHtDmUm8+ZoBUhUbK7OjRqLDSWfYpP19vo9Y86yK/Gek3TCCmn0VZlTdTAYvQv5",
+	"aLQfxmaTZJgaiKQszdsKyTzkTNeSHBJKIoxdRlRJxLfMv+RTpD9mccNyNEwZldPVlpQva684kNZHRBb6",
+	"zXIyJtZK0MnUI1TJiuT5rnXURpsIkprCJg2YxTL9nksiVNDBwrpiybkttofDZ7NiWOP46FBIb5i6UPHx",
+	"yybCqPbQ/qucBIIC35IykOVnNE8EBBRH87EjCbklZWSFJyr2Kcg2pIQKBQKIguRczQ9OP3dqR8OCzym7",
+	"mfWsZ2ahVQVg6iLg0a4abtrU1/yLSkSMyejUY1sCSrKj7ChzaHY8JTILPshGO1PgrFcceSWv1JQC2RWj",
+	"VE3ltkDkSGHyc2h228L5mkof4apMjlWB45Prnm2hid9zapXQpybqWCAfjV171OkUGIn1sdZD1tCEWV0b",
+	"M2HPnCZ2leqnnMc3CG7LnAsQqCSpdSPaqRgFq7usuq/FEqQEYZLEerOhqGMTEyi5pIeUg7Lr2wmMj3yc",
+	"wsZ+5MnGox0VTBWU0UInJctjq2GjGjjeEaoJGRPYaHxxNw/4CJs/aXX+V4IGMqZuQe0NCrOpZpdEKRAM",
+	"n+F/vyEP/rN88Pe3f/kuZJpee6apq68vuNIpmw4HZca3aAVrLkCHiIlr22jqUVyPniAu6hjefVa8+TjQ",
+	"YAeC+S0wxY9ZP8rnId/2piOx5q63V7f2oCA0x2fup39IWmacnWhThV0rDV+ZH9GV/bESekOmVCnPFgt/",
+	"w6AvVm/UH9H55YUxqjEwOyNVH/784npwKC+BSV6JGE64SBf1JrnQazUTqMpheDx6gH4tgel/fX+yxBHe",
+	"gJCWkNOT5cnSTT2RkuIz/P3J8uSR8QIqM7xebE4XxNVMF21ylUKgvP6YFzqyQhozfm9b2kQhMVEUFTbN",
+	"I6bGg7aUJWY4SkPE9De18uB/gnp12hRrf3N5VEkEKUCBkPjsTf/6X3xMmuYMPsPvKxC7VmoaDVeuo9PC",
+	"RIkKonqmbn5XL0hA29Acu/9Fs+JzE3DND71f8Tt7/TU/8HbF7+7lT3klTEyR2ZaptkC64opWJL5BnEXo",
+	"4SNdYExgTapcjVBowWjOwj5NBbm1Hu9vD5ee/zsNzGa8jbArYhpFebhcOusC1qGSssxpbKC++KMOm+c9",
+	"v9fBMOary4Zf/6X1+dFnvNPOswWu+okkyIUP+wj/8CXuNP1gRnJ0BULbELcwwrIqCiJ21nLULQXicSoy",
+	"lqxWDjphw/R2b1nQLj32v0+aJD2n4CLdA5rpphI/UREueVnlRHTfELqvtAs7Vw7beINqkak7Hj6dmHVH",
+	"Hm5fNXakbL62Z86OCT5VLedNWTgJD+8f0dQfvrimDnTFE+VQTRYfLNf3k/pC2khQt2h7vr8e/tKhoalP",
+	"8bWpTTVTtAdUrPFXk4pmV42pmg5n+kD6dCd0l6a+hdK4kX9099B5wRX6hVcs+eZMfNwyaBS0i6ydnxoF",
+	"r+lY+UNEpqraAJrknKUW1hq1dkk96xMhnid645oKqeYB2Y10fYN4HkZ0OkCXJWFN38Q+PEKn2eFgypuN",
+	"mon53szXkKDnXNpuEVNOBjrQE6AqwSJ0ulweJsuM9ISju9Pl8muGd52Rv3u9n9b7WhOzhl3WChzIUJvg",
+	"MKys93nmfZ45fbnXLY3ashckdqannWIzM5N8LDhuy31j1B1VdR2S+TqjcYYkTcD/qz49+CIjW7s7aCc7",
+	"E3nz2OYPlg5p0sMKqOCJnviph3EOU+HN+M2joR2fDBUJtn4jW5eq7DxrpAmpB5pmkMRujiDHm6H6AtWB",
+	"+6rAgapAz1MsTPv8QHZjliJZCiBNAdz/U1pTz5R6xpHkXuKjuDMPZkfBx2JEKznTer73Pfe+Z4bv+WqO",
+	"5esbcZMDdJTMKqavlVohI/NDzNmappWeNa7D/MPk2jGdTobwtVICb2Dvm7LrXzoJGdhyf/7MWXTd8jIS",
+	"KHnoT1oem9kJVHfxumb4kkv16vTKHND8GeVPPNl9Pkl63fb9ft9X0f0ARKef9eoQfy1Dkv/72MAHRgdL",
+	"bXAwiShitjozFA4GIsSZ/ls6mid2dWkGYYSagqKLCO4Ej/0R03tQfpOgbOYRe9D8QJPponzQzplw02Dr",
+	"IjkUaupl6OJJuOZoxtLGo4z+6Mud+sgRJH2dctnQUzXkSSNty+p2tONssch5TPKMS3X24/JHPZHxoTf6",
+	"QUp60pkp0aMsJA1JrdObrIXlt3je7v87AE91P+dKSAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
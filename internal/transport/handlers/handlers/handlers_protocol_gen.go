@@ -7,6 +7,67 @@ import (
 	"time"
 )
 
+// Defines values for AmountType.
+const (
+	From AmountType = "from"
+	To   AmountType = "to"
+)
+
+// Defines values for CandleInterval.
+const (
+	N1d CandleInterval = "1d"
+	N1h CandleInterval = "1h"
+	N1m CandleInterval = "1m"
+)
+
+// Defines values for ExchangeDiagnosticErrorCategory.
+const (
+	ExchangeDiagnosticErrorCategoryBulkheadFull ExchangeDiagnosticErrorCategory = "bulkhead_full"
+	ExchangeDiagnosticErrorCategoryCircuitOpen  ExchangeDiagnosticErrorCategory = "circuit_open"
+	ExchangeDiagnosticErrorCategoryOutOfLimits  ExchangeDiagnosticErrorCategory = "out_of_limits"
+	ExchangeDiagnosticErrorCategoryRateLimited  ExchangeDiagnosticErrorCategory = "rate_limited"
+	ExchangeDiagnosticErrorCategoryRejected     ExchangeDiagnosticErrorCategory = "rejected"
+	ExchangeDiagnosticErrorCategoryTimeout      ExchangeDiagnosticErrorCategory = "timeout"
+	ExchangeDiagnosticErrorCategoryUnavailable  ExchangeDiagnosticErrorCategory = "unavailable"
+	ExchangeDiagnosticErrorCategoryUnknown      ExchangeDiagnosticErrorCategory = "unknown"
+)
+
+// Defines values for ExchangeStatus.
+const (
+	ExchangeStatusError       ExchangeStatus = "error"
+	ExchangeStatusOk          ExchangeStatus = "ok"
+	ExchangeStatusTimeout     ExchangeStatus = "timeout"
+	ExchangeStatusUnsupported ExchangeStatus = "unsupported"
+)
+
+// Defines values for RankStrategy.
+const (
+	Fastest RankStrategy = "fastest"
+	Market  RankStrategy = "market"
+	Payout  RankStrategy = "payout"
+)
+
+// Defines values for RateMode.
+const (
+	Fixed    RateMode = "fixed"
+	Floating RateMode = "floating"
+)
+
+// AmountType from when the amount sent is fixed, to when the amount received is
+type AmountType string
+
+// Candle defines model for Candle.
+type Candle struct {
+	Close    float64   `json:"close"`
+	High     float64   `json:"high"`
+	Low      float64   `json:"low"`
+	Open     float64   `json:"open"`
+	OpenTime time.Time `json:"openTime"`
+}
+
+// CandleInterval defines model for CandleInterval.
+type CandleInterval string
+
 // Currency defines model for Currency.
 type Currency struct {
 	AddressValidation string    `json:"addressValidation"`
@@ -23,9 +84,57 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// ExchangeDiagnostic defines model for ExchangeDiagnostic.
+type ExchangeDiagnostic struct {
+	// ErrorCategory Why the exchange didn't quote
+	ErrorCategory *ExchangeDiagnosticErrorCategory `json:"errorCategory,omitempty"`
+	Exchange      string                           `json:"exchange"`
+	LatencyMs     int64                            `json:"latencyMs"`
+	Status        ExchangeStatus                   `json:"status"`
+}
+
+// ExchangeDiagnosticErrorCategory Why the exchange didn't quote
+type ExchangeDiagnosticErrorCategory string
+
+// ExchangeStats defines model for ExchangeStats.
+type ExchangeStats struct {
+	// AvgDifference Mean rate of the answered quotes against the market price, in percent
+	AvgDifference float64 `json:"avgDifference"`
+	AvgLatencyMs  int64   `json:"avgLatencyMs"`
+	Exchange      string  `json:"exchange"`
+	MaxLatencyMs  int64   `json:"maxLatencyMs"`
+
+	// Quotes Quote requests the exchange answered
+	Quotes int `json:"quotes"`
+
+	// Requests Quote requests the exchange was asked
+	Requests int     `json:"requests"`
+	WinRate  float64 `json:"winRate"`
+
+	// Wins Quote requests the exchange gave the best payout of
+	Wins int `json:"wins"`
+}
+
+// ExchangeStatus defines model for ExchangeStatus.
+type ExchangeStatus string
+
+// Limits defines model for Limits.
+type Limits struct {
+	Max *float64 `json:"max,omitempty"`
+	Min float64  `json:"min"`
+}
+
 // Network defines model for Network.
 type Network struct {
-	Name string `json:"name"`
+	Contract          *string  `json:"contract,omitempty"`
+	DisplayName       string   `json:"displayName"`
+	Exchanges         []string `json:"exchanges"`
+	ExtraIdValidation *string  `json:"extraIdValidation,omitempty"`
+	HasExtraId        bool     `json:"hasExtraId"`
+	Name              string   `json:"name"`
+
+	// Warnings Warnings of each exchange listing the network
+	Warnings *map[string]Warnings `json:"warnings,omitempty"`
 }
 
 // NetworkPair defines model for NetworkPair.
@@ -34,30 +143,161 @@ type NetworkPair struct {
 	Symbol  Symbol `json:"symbol"`
 }
 
+// PriceHistory defines model for PriceHistory.
+type PriceHistory struct {
+	Candles []Candle `json:"candles"`
+
+	// Change24h Price change over the previous day, in percent
+	Change24h float64        `json:"change24h"`
+	Interval  CandleInterval `json:"interval"`
+
+	// Price Latest price in USD
+	Price  float64 `json:"price"`
+	Symbol string  `json:"symbol"`
+}
+
 // Quote defines model for Quote.
 type Quote struct {
-	Amount     float64     `json:"amount"`
+	// Amount Amount received
+	Amount float64 `json:"amount"`
+
+	// AmountType from when the amount sent is fixed, to when the amount received is
+	AmountType AmountType `json:"amountType"`
+
+	// Best Whether this is the top ranked quote
+	Best       bool        `json:"best"`
 	Difference float64     `json:"difference"`
 	Exchange   string      `json:"exchange"`
 	From       NetworkPair `json:"from"`
+
+	// FromAmount Amount sent
+	FromAmount float64     `json:"fromAmount"`
+	Limits     *Limits     `json:"limits,omitempty"`
+	RateId     *string     `json:"rateId,omitempty"`
+	RateMode   RateMode    `json:"rateMode"`
 	To         NetworkPair `json:"to"`
+	ValidUntil *time.Time  `json:"validUntil,omitempty"`
+
+	// Warnings Notices to show before sending the from currency or receiving the to currency
+	Warnings     Warnings `json:"warnings"`
+	WithinLimits bool     `json:"withinLimits"`
+}
+
+// QuoteAnalytics defines model for QuoteAnalytics.
+type QuoteAnalytics struct {
+	Exchanges []ExchangeStats `json:"exchanges"`
+	From      NetworkPair     `json:"from"`
+	Since     time.Time       `json:"since"`
+	To        NetworkPair     `json:"to"`
+}
+
+// Quotes defines model for Quotes.
+type Quotes struct {
+	// Exchanges How every exchange answered the quote request
+	Exchanges []ExchangeDiagnostic `json:"exchanges"`
+	Quotes    []Quote              `json:"quotes"`
+
+	// Routes Two-leg routes through a bridge asset, only looked for when no exchange quotes the pair directly
+	Routes []Route `json:"routes"`
+}
+
+// RankStrategy payout ranks by amount received, market by rate against the market price, fastest by response time
+type RankStrategy string
+
+// RateMode defines model for RateMode.
+type RateMode string
+
+// Route defines model for Route.
+type Route struct {
+	Amount float64 `json:"amount"`
+
+	// Difference Route rate against the market price, in percent
+	Difference float64 `json:"difference"`
+
+	// EffectiveRate Amount received per unit sent
+	EffectiveRate float64 `json:"effectiveRate"`
+
+	// FeesUsd Value lost across the legs at market prices, in USD
+	FeesUsd    float64 `json:"feesUsd"`
+	FromAmount float64 `json:"fromAmount"`
+
+	// Legs Quotes of each leg, in the order they have to be run
+	Legs []Quote     `json:"legs"`
+	Via  NetworkPair `json:"via"`
+}
+
+// SplitQuote defines model for SplitQuote.
+type SplitQuote struct {
+	Amount     float64 `json:"amount"`
+	FromAmount float64 `json:"fromAmount"`
+
+	// Gain Amount received over the best single exchange
+	Gain float64 `json:"gain"`
+
+	// Quotes Quote of each part, on its own exchange
+	Quotes []Quote `json:"quotes"`
+}
+
+// SplitSwapRequest defines model for SplitSwapRequest.
+type SplitSwapRequest struct {
+	From NetworkPair `json:"from"`
+
+	// Parts Amount sent to each exchange, usually the quotes of a split quote
+	Parts         []SwapPart  `json:"parts"`
+	RefundAddress string      `json:"refundAddress"`
+	RefundExtraId *string     `json:"refundExtraId,omitempty"`
+	To            NetworkPair `json:"to"`
+	ToAddress     string      `json:"toAddress"`
+	ToExtraId     *string     `json:"toExtraId,omitempty"`
 }
 
 // Swap defines model for Swap.
 type Swap struct {
-	CreatedAt     time.Time   `json:"createdAt"`
-	Exchange      string      `json:"exchange"`
-	From          NetworkPair `json:"from"`
-	Id            string      `json:"id"`
+	// Children Parts of a split swap, each run on its own exchange
+	Children  *[]Swap     `json:"children,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Exchange  string      `json:"exchange"`
+	From      NetworkPair `json:"from"`
+	Id        string      `json:"id"`
+
+	// Links Block explorer pages of the swap addresses and transactions
+	Links SwapLinks `json:"links"`
+
+	// ParentId Split swap this swap is a part of
+	ParentId      *string     `json:"parentId,omitempty"`
 	PayinAmount   float64     `json:"payinAmount"`
+	PayinHash     *string     `json:"payinHash,omitempty"`
 	PayoutAddress string      `json:"payoutAddress"`
 	PayoutAmount  float64     `json:"payoutAmount"`
+	PayoutExtraId *string     `json:"payoutExtraId,omitempty"`
+	PayoutHash    *string     `json:"payoutHash,omitempty"`
+	RateId        *string     `json:"rateId,omitempty"`
 	Reason        string      `json:"reason"`
 	RefundAddress string      `json:"refundAddress"`
+	RefundExtraId *string     `json:"refundExtraId,omitempty"`
 	Status        string      `json:"status"`
 	To            NetworkPair `json:"to"`
 	ToAddress     string      `json:"toAddress"`
+	ToExtraId     *string     `json:"toExtraId,omitempty"`
 	UpdatedAt     time.Time   `json:"updatedAt"`
+
+	// Warnings Notices to show before sending the from currency or receiving the to currency
+	Warnings *Warnings `json:"warnings,omitempty"`
+}
+
+// SwapLinks Block explorer pages of the swap addresses and transactions
+type SwapLinks struct {
+	DepositAddress *string `json:"depositAddress,omitempty"`
+	PayinTx        *string `json:"payinTx,omitempty"`
+	PayoutTx       *string `json:"payoutTx,omitempty"`
+	ToAddress      *string `json:"toAddress,omitempty"`
+}
+
+// SwapPart defines model for SwapPart.
+type SwapPart struct {
+	Amount   float64 `json:"amount"`
+	Exchange string  `json:"exchange"`
+	RateId   *string `json:"rateId,omitempty"`
 }
 
 // SwapRequest defines model for SwapRequest.
@@ -65,14 +305,41 @@ type SwapRequest struct {
 	Amount        float64     `json:"amount"`
 	Exchange      string      `json:"exchange"`
 	From          NetworkPair `json:"from"`
+	RateId        *string     `json:"rateId,omitempty"`
 	RefundAddress string      `json:"refundAddress"`
+	RefundExtraId *string     `json:"refundExtraId,omitempty"`
 	To            NetworkPair `json:"to"`
 	ToAddress     string      `json:"toAddress"`
+	ToExtraId     *string     `json:"toExtraId,omitempty"`
 }
 
 // Symbol defines model for Symbol.
 type Symbol = string
 
+// Warnings Notices to show before sending the from currency or receiving the to currency
+type Warnings struct {
+	From []string `json:"from"`
+	To   []string `json:"to"`
+}
+
+// GetV1AnalyticsQuotesParams defines parameters for GetV1AnalyticsQuotes.
+type GetV1AnalyticsQuotesParams struct {
+	// FromSymbol From currency
+	FromSymbol Symbol `form:"fromSymbol" json:"fromSymbol"`
+
+	// FromNetwork From network
+	FromNetwork Symbol `form:"fromNetwork" json:"fromNetwork"`
+
+	// ToSymbol To currency
+	ToSymbol Symbol `form:"toSymbol" json:"toSymbol"`
+
+	// ToNetwork To network
+	ToNetwork Symbol `form:"toNetwork" json:"toNetwork"`
+
+	// WindowHours Hours of history to look back on, 24 by default
+	WindowHours *int `form:"windowHours,omitempty" json:"windowHours,omitempty"`
+}
+
 // GetV1CurrenciesParams defines parameters for GetV1Currencies.
 type GetV1CurrenciesParams struct {
 	// Name Name of the currency
@@ -88,6 +355,15 @@ type GetV1CurrenciesParams struct {
 	Symbols *[]string `form:"symbols,omitempty" json:"symbols,omitempty"`
 }
 
+// GetV1CurrenciesSymbolHistoryParams defines parameters for GetV1CurrenciesSymbolHistory.
+type GetV1CurrenciesSymbolHistoryParams struct {
+	// Interval Time span of each candle, 1h by default
+	Interval *CandleInterval `form:"interval,omitempty" json:"interval,omitempty"`
+
+	// Limit Most recent candles to return, 100 by default
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetV1QuotesParams defines parameters for GetV1Quotes.
 type GetV1QuotesParams struct {
 	// FromSymbol From currency
@@ -102,9 +378,45 @@ type GetV1QuotesParams struct {
 	// ToNetwork To network
 	ToNetwork Symbol `form:"toNetwork" json:"toNetwork"`
 
-	// Amount Amount
+	// Amount Amount sent, or received when amountType is to
 	Amount float64 `form:"amount" json:"amount"`
+
+	// AmountType Which side the amount fixes, from by default
+	AmountType *AmountType `form:"amountType,omitempty" json:"amountType,omitempty"`
+
+	// RateMode Rate mode, floating by default
+	RateMode *RateMode `form:"rateMode,omitempty" json:"rateMode,omitempty"`
+
+	// Rank How the quotes are ranked, by payout by default
+	Rank *RankStrategy `form:"rank,omitempty" json:"rank,omitempty"`
+}
+
+// GetV1QuotesSplitParams defines parameters for GetV1QuotesSplit.
+type GetV1QuotesSplitParams struct {
+	// FromSymbol From currency
+	FromSymbol Symbol `form:"fromSymbol" json:"fromSymbol"`
+
+	// FromNetwork From network
+	FromNetwork Symbol `form:"fromNetwork" json:"fromNetwork"`
+
+	// ToSymbol To currency
+	ToSymbol Symbol `form:"toSymbol" json:"toSymbol"`
+
+	// ToNetwork To network
+	ToNetwork Symbol `form:"toNetwork" json:"toNetwork"`
+
+	// Amount Amount sent
+	Amount float64 `form:"amount" json:"amount"`
+
+	// RateMode Rate mode, floating by default
+	RateMode *RateMode `form:"rateMode,omitempty" json:"rateMode,omitempty"`
+
+	// Parts Most exchanges to spread the amount over, the configured maximum by default
+	Parts *int `form:"parts,omitempty" json:"parts,omitempty"`
 }
 
 // PostV1SwapsJSONRequestBody defines body for PostV1Swaps for application/json ContentType.
 type PostV1SwapsJSONRequestBody = SwapRequest
+
+// PostV1SwapsSplitJSONRequestBody defines body for PostV1SwapsSplit for application/json ContentType.
+type PostV1SwapsSplitJSONRequestBody = SplitSwapRequest
@@ -2,44 +2,51 @@ package handlers
 
 import (
 	"cryptoswap/internal/services/models"
+	"time"
 
 	"github.com/samber/lo"
 )
 
 func toCurrencies(currencies []models.Currency) []Currency {
 	return lo.Map(currencies, func(currency models.Currency, _ int) Currency {
-		return Currency{
-			Name:              currency.Name,
-			Symbol:            currency.Symbol,
-			Image:             currency.Image,
-			Available:         currency.Available,
-			AddressValidation: currency.AddressValidation,
-			Price:             currency.Price,
-			Networks:          toNetworks(currency.GetNetworks()),
-		}
+		return toCurrency(currency)
 	})
 }
 
-func toNetworks(networks []models.NetworkPair) []Network {
-	return lo.Map(networks, func(network models.NetworkPair, _ int) Network {
+func toCurrency(currency models.Currency) Currency {
+	return Currency{
+		Name:              currency.Name,
+		Symbol:            currency.Symbol,
+		Image:             currency.Image,
+		Available:         currency.Available,
+		AddressValidation: currency.AddressValidation,
+		Price:             currency.Price,
+		Networks:          toNetworks(currency),
+	}
+}
+
+func toNetworks(currency models.Currency) []Network {
+	return lo.Map(currency.GetNetworks(), func(network models.NetworkPair, _ int) Network {
+		info := currency.GetNetworkInfo(network)
 		return Network{
-			Name: network.Network,
+			Name:              network.Network,
+			DisplayName:       models.GetNetworkName(network.Network),
+			Contract:          lo.EmptyableToPtr(info.Contract),
+			HasExtraId:        info.HasExtraId,
+			ExtraIdValidation: lo.EmptyableToPtr(info.ExtraIdValidation),
+			Exchanges:         lo.Ternary(info.Exchanges != nil, info.Exchanges, []string{}),
+			Warnings:          toNetworkWarnings(info.Warnings),
 		}
 	})
 }
 
 func toPairFromRequest(pair NetworkPair) models.NetworkPair {
-	return models.NetworkPair{
-		Symbol:  pair.Symbol,
-		Network: pair.Network,
-	}
+	return toPair(pair.Symbol, pair.Network)
 }
 
+// toPair accepts any known alias of the network, like erc20 for eth.
 func toPair(symbol, network string) models.NetworkPair {
-	return models.NetworkPair{
-		Symbol:  symbol,
-		Network: network,
-	}
+	return models.NewNetworkPair(symbol, models.CanonicalNetwork(network))
 }
 
 func toFilter(filter GetV1CurrenciesParams) models.Filters {
@@ -54,15 +61,72 @@ func toFilter(filter GetV1CurrenciesParams) models.Filters {
 func toQuotes(quotes []models.Quote) []Quote {
 	return lo.Map(quotes, func(quote models.Quote, _ int) Quote {
 		return Quote{
-			From:       fromPair(quote.From),
-			To:         fromPair(quote.To),
-			Amount:     quote.Amount,
-			Exchange:   quote.Exchange,
-			Difference: quote.Difference,
+			From:         fromPair(quote.From),
+			To:           fromPair(quote.To),
+			FromAmount:   quote.FromAmount,
+			Amount:       quote.Amount,
+			AmountType:   AmountType(quote.AmountType),
+			Exchange:     quote.Exchange,
+			Difference:   quote.Difference,
+			Limits:       toLimits(quote.Limits),
+			WithinLimits: quote.WithinLimits,
+			RateMode:     RateMode(quote.RateMode),
+			RateId:       lo.EmptyableToPtr(quote.RateId),
+			ValidUntil:   lo.EmptyableToPtr(quote.ValidUntil),
+			Warnings:     toWarnings(quote.Warnings),
+			Best:         quote.Best,
 		}
 	})
 }
 
+func toQuoteResult(result models.QuoteResult) Quotes {
+	return Quotes{
+		Quotes: toQuotes(result.Quotes),
+		Routes: lo.Map(result.Routes, func(route models.Route, _ int) Route {
+			return Route{
+				Via:           fromPair(route.Via),
+				Legs:          toQuotes(route.Legs),
+				FromAmount:    route.FromAmount,
+				Amount:        route.Amount,
+				EffectiveRate: route.EffectiveRate,
+				Difference:    route.Difference,
+				FeesUsd:       route.FeesUsd,
+			}
+		}),
+		Exchanges: lo.Map(result.Exchanges, func(diagnostic models.ExchangeDiagnostic, _ int) ExchangeDiagnostic {
+			return ExchangeDiagnostic{
+				Exchange:      diagnostic.Exchange,
+				Status:        ExchangeStatus(diagnostic.Status),
+				LatencyMs:     diagnostic.Latency.Milliseconds(),
+				ErrorCategory: (*ExchangeDiagnosticErrorCategory)(lo.EmptyableToPtr(diagnostic.ErrorCategory)),
+			}
+		}),
+	}
+}
+
+func toAmountType(amountType *AmountType) models.AmountType {
+	return models.NewAmountType(string(lo.FromPtr(amountType)))
+}
+
+func toRankStrategy(rank *RankStrategy) models.RankStrategy {
+	return models.NewRankStrategy(string(lo.FromPtr(rank)))
+}
+
+func toRateMode(rate *RateMode) models.RateMode {
+	return models.NewRateMode(string(lo.FromPtr(rate)))
+}
+
+func toLimits(limits models.Limits) *Limits {
+	if limits.IsEmpty() {
+		return nil
+	}
+
+	return &Limits{
+		Min: limits.Min,
+		Max: lo.Ternary(limits.Max != 0, &limits.Max, nil),
+	}
+}
+
 func fromPair(pair models.NetworkPair) NetworkPair {
 	return NetworkPair{
 		Symbol:  pair.Symbol,
@@ -82,8 +146,113 @@ func toSwap(swap models.Swap) Swap {
 		UpdatedAt:     swap.UpdatedAt,
 		Reason:        swap.Reason,
 		PayoutAddress: swap.PayoutAddress,
+		PayoutExtraId: lo.EmptyableToPtr(swap.PayoutExtraId),
 		PayoutAmount:  swap.PayoutAmount,
 		ToAddress:     swap.ToAddress,
+		ToExtraId:     lo.EmptyableToPtr(swap.ToExtraId),
 		RefundAddress: swap.RefundAddress,
+		RefundExtraId: lo.EmptyableToPtr(swap.RefundExtraId),
+		RateId:        lo.EmptyableToPtr(swap.RateId),
+		PayinHash:     lo.EmptyableToPtr(swap.PayinHash),
+		PayoutHash:    lo.EmptyableToPtr(swap.PayoutHash),
+		Links:         toSwapLinks(swap.Links),
+		Warnings:      lo.Ternary(swap.Warnings.IsEmpty(), nil, lo.ToPtr(toWarnings(swap.Warnings))),
+		ParentId:      lo.EmptyableToPtr(swap.ParentId),
+		Children:      lo.Ternary(len(swap.Children) == 0, nil, lo.ToPtr(lo.Map(swap.Children, toChildSwap))),
+	}
+}
+
+func toChildSwap(swap models.Swap, _ int) Swap {
+	return toSwap(swap)
+}
+
+func toSplitQuote(split models.SplitQuote) SplitQuote {
+	return SplitQuote{
+		Quotes:     toQuotes(split.Quotes),
+		FromAmount: split.FromAmount,
+		Amount:     split.Amount,
+		Gain:       split.Gain,
+	}
+}
+
+func toSwapParts(parts []SwapPart) []models.SwapPart {
+	return lo.Map(parts, func(part SwapPart, _ int) models.SwapPart {
+		return models.SwapPart{
+			Exchange: part.Exchange,
+			Amount:   part.Amount,
+			RateId:   lo.FromPtr(part.RateId),
+		}
+	})
+}
+
+func toNetworkWarnings(warnings map[string]models.Warnings) *map[string]Warnings {
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	return lo.ToPtr(lo.MapValues(warnings, func(warnings models.Warnings, _ string) Warnings {
+		return toWarnings(warnings)
+	}))
+}
+
+func toWarnings(warnings models.Warnings) Warnings {
+	return Warnings{
+		From: lo.Ternary(warnings.From != nil, warnings.From, []string{}),
+		To:   lo.Ternary(warnings.To != nil, warnings.To, []string{}),
+	}
+}
+
+func toSwapLinks(links models.SwapLinks) SwapLinks {
+	return SwapLinks{
+		DepositAddress: lo.EmptyableToPtr(links.DepositAddress),
+		ToAddress:      lo.EmptyableToPtr(links.ToAddress),
+		PayinTx:        lo.EmptyableToPtr(links.PayinTx),
+		PayoutTx:       lo.EmptyableToPtr(links.PayoutTx),
+	}
+}
+
+// toWindow defaults to the last day when no window is given.
+func toWindow(hours *int) time.Duration {
+	if hours == nil {
+		return 24 * time.Hour
+	}
+	return time.Duration(*hours) * time.Hour
+}
+
+func toQuoteAnalytics(analytics models.PairAnalytics) QuoteAnalytics {
+	return QuoteAnalytics{
+		From:  fromPair(analytics.From),
+		To:    fromPair(analytics.To),
+		Since: analytics.Since,
+		Exchanges: lo.Map(analytics.Exchanges, func(stats models.ExchangeStats, _ int) ExchangeStats {
+			return ExchangeStats{
+				Exchange:      stats.Exchange,
+				Requests:      stats.Requests,
+				Quotes:        stats.Quotes,
+				Wins:          stats.Wins,
+				WinRate:       stats.GetWinRate(),
+				AvgDifference: stats.AvgDifference,
+				AvgLatencyMs:  stats.AvgLatency.Milliseconds(),
+				MaxLatencyMs:  stats.MaxLatency.Milliseconds(),
+			}
+		}),
+	}
+}
+
+func toPriceHistory(history models.PriceHistory) PriceHistory {
+	return PriceHistory{
+		Symbol:    history.Symbol,
+		Price:     history.Price,
+		Change24h: history.Change24h,
+		Interval:  CandleInterval(history.Interval),
+		Candles: lo.Map(history.Candles, func(candle models.Candle, _ int) Candle {
+			return Candle{
+				OpenTime: candle.OpenTime,
+				Open:     candle.Open,
+				High:     candle.High,
+				Low:      candle.Low,
+				Close:    candle.Close,
+			}
+		}),
 	}
 }